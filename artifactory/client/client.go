@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -137,7 +136,8 @@ func (c *Client) NewURLEncodedRequest(method, urlStr string, body interface{}) (
 
 // Do executes a give request with the given context. If the parameter v is a writer the body will be written to it in
 // raw format, else v is assumed to be a struct to unmarshal the body into assuming JSON format. If v is nil then the
-// body is not read and can be manually parsed from the response
+// body is not read and can be manually parsed from the response. Writers implementing ContentLengthReceiver are told
+// the length of the body before it is written
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	req = req.WithContext(ctx)
 	resp, err := c.client.Do(req)
@@ -170,6 +170,9 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
+			if r, ok := v.(ContentLengthReceiver); ok {
+				r.SetContentLength(resp.ContentLength)
+			}
 			io.Copy(w, resp.Body)
 		} else {
 			err = json.NewDecoder(resp.Body).Decode(v)
@@ -214,7 +217,7 @@ func checkResponse(r *http.Response) error {
 	if err == nil && data != nil {
 		err = json.Unmarshal(data, errorResponse)
 		if err != nil || len(errorResponse.Errors) == 0 {
-			return fmt.Errorf(string(data))
+			return &RawErrorResponse{Response: r, Body: data}
 		}
 	}
 
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// OneOrMany decodes the JSON values which are either a single element or an array of elements, as returned by the
+// endpoints that don't wrap a single result in an array. Both shapes are appended to the slice given to NewOneOrMany,
+// so it can be passed as is to Client.Do:
+//
+//	replications := make([]SingleReplicationConfig, 0)
+//	resp, err := c.Do(ctx, req, client.NewOneOrMany(&replications))
+//
+// An empty body or a null value leaves the slice unchanged.
+type OneOrMany struct {
+	slice interface{}
+}
+
+// NewOneOrMany returns a decoder into slice, which must be a pointer to a slice
+func NewOneOrMany(slice interface{}) *OneOrMany {
+	return &OneOrMany{slice: slice}
+}
+
+func (o *OneOrMany) UnmarshalJSON(data []byte) error {
+	v := reflect.ValueOf(o.slice)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("one or many target must be a non nil pointer to a slice, got %T", o.slice)
+	}
+	slice := v.Elem()
+
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		return nil
+	case data[0] == '[':
+		elems := reflect.New(slice.Type())
+		if err := json.Unmarshal(data, elems.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.AppendSlice(slice, elems.Elem()))
+	default:
+		elem := reflect.New(slice.Type().Elem())
+		if err := json.Unmarshal(data, elem.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, elem.Elem()))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type oneOrManyItem struct {
+	Key string `json:"key"`
+}
+
+func TestOneOrMany(t *testing.T) {
+	var items []oneOrManyItem
+	assert.Nil(t, json.Unmarshal([]byte(`{"key": "a"}`), NewOneOrMany(&items)))
+	assert.Equal(t, []oneOrManyItem{{Key: "a"}}, items)
+
+	items = nil
+	assert.Nil(t, json.Unmarshal([]byte(` [{"key": "a"}, {"key": "b"}]`), NewOneOrMany(&items)))
+	assert.Equal(t, []oneOrManyItem{{Key: "a"}, {Key: "b"}}, items)
+
+	items = make([]oneOrManyItem, 0)
+	assert.Nil(t, json.Unmarshal([]byte(`null`), NewOneOrMany(&items)))
+	assert.Equal(t, []oneOrManyItem{}, items)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"a"`), NewOneOrMany(&items)))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"key": "a"}`), NewOneOrMany(items)))
+}
+
+func TestOneOrManyResponse(t *testing.T) {
+	bodies := map[string]string{
+		"/single": `{"key": "a"}`,
+		"/array":  `[{"key": "a"}, {"key": "b"}]`,
+		"/empty":  ``,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, bodies[r.URL.Path])
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL, http.DefaultClient)
+	assert.Nil(t, err)
+
+	expected := map[string][]oneOrManyItem{
+		"/single": {{Key: "a"}},
+		"/array":  {{Key: "a"}, {Key: "b"}},
+		"/empty":  {},
+	}
+	for path, want := range expected {
+		req, err := c.NewRequest("GET", path, nil)
+		assert.Nil(t, err)
+		items := make([]oneOrManyItem, 0)
+		_, err = c.Do(context.Background(), req, NewOneOrMany(&items))
+		assert.Nil(t, err, path)
+		assert.Equal(t, want, items, path)
+	}
+}
@@ -25,3 +25,14 @@ func (r *ErrorResponse) Error() string {
 	return fmt.Sprintf("%v %v: %d %+v", r.Response.Request.Method, r.Response.Request.URL,
 		r.Response.StatusCode, r.Errors)
 }
+
+// RawErrorResponse reports an API request failure whose body isn't a list of errors. The body is kept as it was
+// received, so the callers can decode the API specific error payloads.
+type RawErrorResponse struct {
+	Response *http.Response // HTTP response that caused this error
+	Body     []byte         // Body of the response
+}
+
+func (r *RawErrorResponse) Error() string {
+	return string(r.Body)
+}
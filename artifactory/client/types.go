@@ -3,3 +3,9 @@ package client
 type Service struct {
 	Client *Client
 }
+
+// ContentLengthReceiver can be implemented by the writers given to Client.Do to be told the length of the response
+// body before it is copied to them. The length is -1 when unknown.
+type ContentLengthReceiver interface {
+	SetContentLength(length int64)
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFields are the JSON attributes of an object which its Go type doesn't model, as returned by the versions of
+// Artifactory newer than the client. They are kept as is so that they can be sent back unchanged.
+type UnknownFields map[string]json.RawMessage
+
+// UnmarshalKnownFields decodes data into v, a pointer to a struct, and returns the attributes of data which match no
+// field of the struct, nil if there is none. As encoding/json, attribute names are matched case insensitively. The
+// type of v must not implement json.Unmarshaler itself, it's usually a type defined on the type implementing it:
+//
+//	func (r *User) UnmarshalJSON(data []byte) error {
+//		type user User
+//		unknown, err := client.UnmarshalKnownFields(data, (*user)(r))
+//		r.unknownFields = unknown
+//		return err
+//	}
+func UnmarshalKnownFields(data []byte, v interface{}) (UnknownFields, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	var unknown UnknownFields
+	for name, value := range attributes {
+		if isKnownField(known, name) {
+			continue
+		}
+		if unknown == nil {
+			unknown = make(UnknownFields)
+		}
+		unknown[name] = value
+	}
+	return unknown, nil
+}
+
+// MarshalWithUnknownFields encodes v, a struct or a pointer to a struct, then appends the unknown fields in the
+// order of their names. The type of v must not implement json.Marshaler itself. Unknown fields named after a field
+// of the struct are left out, the struct being authoritative.
+func MarshalWithUnknownFields(v interface{}, unknown UnknownFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(unknown) == 0 {
+		return data, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 || data[0] != '{' || data[len(data)-1] != '}' {
+		return nil, fmt.Errorf("unknown fields can't be added to %T, it isn't encoded as an object", v)
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	names := make([]string, 0, len(unknown))
+	for name := range unknown {
+		if !isKnownField(known, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	buf.Write(data[:len(data)-1])
+	empty := len(bytes.TrimSpace(data[1:len(data)-1])) == 0
+	for _, name := range names {
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value := unknown[name]
+		if len(value) == 0 {
+			value = json.RawMessage("null")
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// knownFields returns the JSON names of the fields of a struct type, including the fields of its embedded structs
+func knownFields(t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	names := make([]string, 0)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			names = append(names, knownFields(f.Type)...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func isKnownField(known []string, name string) bool {
+	for _, k := range known {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
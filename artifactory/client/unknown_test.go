@@ -0,0 +1,63 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type base struct {
+	Id *string `json:"id,omitempty"`
+}
+
+type modelled struct {
+	base
+	Name   *string `json:"name,omitempty"`
+	Count  int
+	Hidden string `json:"-"`
+	secret string
+}
+
+func TestUnmarshalKnownFields(t *testing.T) {
+	v := new(modelled)
+	unknown, err := UnmarshalKnownFields([]byte(`{"id": "1", "NAME": "a", "count": 2, "Hidden": "h", "secret": "s", "extra": {"b": [1]}}`), v)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", *v.Id)
+	assert.Equal(t, "a", *v.Name)
+	assert.Equal(t, 2, v.Count)
+	assert.Equal(t, UnknownFields{
+		"Hidden": json.RawMessage(`"h"`),
+		"secret": json.RawMessage(`"s"`),
+		"extra":  json.RawMessage(`{"b": [1]}`),
+	}, unknown)
+
+	unknown, err = UnmarshalKnownFields([]byte(`{"name": "a"}`), new(modelled))
+	assert.Nil(t, err)
+	assert.Nil(t, unknown)
+
+	_, err = UnmarshalKnownFields([]byte(`{"name": 1}`), new(modelled))
+	assert.NotNil(t, err)
+}
+
+func TestMarshalWithUnknownFields(t *testing.T) {
+	name := "a"
+	data, err := MarshalWithUnknownFields(modelled{Name: &name}, UnknownFields{
+		"zeta":  json.RawMessage(`true`),
+		"alpha": json.RawMessage(`{"b": 1}`),
+		"name":  json.RawMessage(`"ignored"`),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name":"a","Count":0,"alpha":{"b": 1},"zeta":true}`, string(data))
+
+	data, err = MarshalWithUnknownFields(struct{}{}, UnknownFields{"extra": json.RawMessage(`1`)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"extra":1}`, string(data))
+
+	data, err = MarshalWithUnknownFields(modelled{}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Count":0}`, string(data))
+
+	_, err = MarshalWithUnknownFields([]string{"a"}, UnknownFields{"extra": json.RawMessage(`1`)})
+	assert.NotNil(t, err)
+}
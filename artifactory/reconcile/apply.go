@@ -0,0 +1,104 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/v1"
+	log "github.com/sirupsen/logrus"
+)
+
+// Apply applies the changes of the plan in order, and stops at the first failure. It returns the changes applied
+// before the failure, if any. The updates only send the changed attributes, the write only ones like the password
+// included, and fail with a v1.RepositoryConflictError if the repository was changed since the plan.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) ([]Change, error) {
+	applied := make([]Change, 0, len(plan.Changes))
+	for _, c := range plan.Changes {
+		log.Debugf("[Artifactory Client] Reconcile: %s %s repository [%s]", c.Action, c.RClass, c.Key)
+		var err error
+		switch c.Action {
+		case ActionCreate:
+			err = r.create(ctx, c.Desired)
+		case ActionUpdate:
+			err = r.update(ctx, c)
+		case ActionDelete:
+			err = r.delete(ctx, c.Key, c.RClass)
+		default:
+			err = fmt.Errorf("unsupported action [%s]", c.Action)
+		}
+		if err != nil {
+			return applied, fmt.Errorf("%s %s repository [%s]: %v", c.Action, c.RClass, c.Key, err)
+		}
+		applied = append(applied, c)
+	}
+	return applied, nil
+}
+
+func (r *Reconciler) create(ctx context.Context, repository v1.Repository) error {
+	var err error
+	switch repo := pointerTo(repository).(type) {
+	case *v1.LocalRepository:
+		_, err = r.repositories.CreateLocal(ctx, repo)
+	case *v1.RemoteRepository:
+		_, err = r.repositories.CreateRemote(ctx, repo)
+	case *v1.VirtualRepository:
+		_, err = r.repositories.CreateVirtual(ctx, repo)
+	case *v1.DistributionRepository:
+		_, err = r.repositories.CreateDistribution(ctx, repo)
+	case *v1.FederatedRepository:
+		_, err = r.repositories.CreateFederated(ctx, repo)
+	default:
+		err = fmt.Errorf("unsupported repository type %T", repository)
+	}
+	return err
+}
+
+// update sends the changed attributes of the repository, provided it wasn't changed since the plan
+func (r *Reconciler) update(ctx context.Context, c Change) error {
+	opt := new(v1.RepositoryPatchOptions)
+	if c.Current != nil {
+		revision, err := v1.RepositoryRevision(c.Current)
+		if err != nil {
+			return err
+		}
+		opt.ExpectedRevision = revision
+	}
+	_, _, err := r.repositories.Patch(ctx, c.Desired, opt)
+	return err
+}
+
+func (r *Reconciler) delete(ctx context.Context, key string, rclass string) error {
+	var err error
+	switch rclass {
+	case v1.RepositoryClassLocal:
+		_, err = r.repositories.DeleteLocal(ctx, key)
+	case v1.RepositoryClassRemote:
+		_, err = r.repositories.DeleteRemote(ctx, key)
+	case v1.RepositoryClassVirtual:
+		_, err = r.repositories.DeleteVirtual(ctx, key)
+	case v1.RepositoryClassDistribution:
+		_, err = r.repositories.DeleteDistribution(ctx, key)
+	case v1.RepositoryClassFederated:
+		_, err = r.repositories.DeleteFederated(ctx, key)
+	default:
+		err = fmt.Errorf("unsupported class [%s]", rclass)
+	}
+	return err
+}
+
+// pointerTo returns a pointer to the repositories given by value
+func pointerTo(repository v1.Repository) v1.Repository {
+	switch repo := repository.(type) {
+	case v1.LocalRepository:
+		return &repo
+	case v1.RemoteRepository:
+		return &repo
+	case v1.VirtualRepository:
+		return &repo
+	case v1.DistributionRepository:
+		return &repo
+	case v1.FederatedRepository:
+		return &repo
+	}
+	return repository
+}
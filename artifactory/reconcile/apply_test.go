@@ -0,0 +1,118 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	f := newFakeArtifactory(
+		`{"key": "libs-local", "rclass": "local", "packageType": "maven", "description": "old"}`,
+		`{"key": "old", "rclass": "virtual", "packageType": "maven", "repositories": ["old-local"]}`,
+		`{"key": "old-local", "rclass": "local", "packageType": "maven"}`,
+	)
+	r, closeServer := newTestReconciler(f)
+	defer closeServer()
+	ctx := context.Background()
+
+	desired := mustParse(t, `
+- key: libs
+  rclass: virtual
+  packageType: maven
+  repositories: [libs-local]
+- key: libs-local
+  rclass: local
+  packageType: maven
+  description: libraries
+`)
+	plan, err := r.Plan(ctx, desired, &PlanOptions{Delete: true})
+	if !assert.Nil(t, err) {
+		return
+	}
+	applied, err := r.Apply(ctx, plan)
+	assert.Nil(t, err)
+	assert.Len(t, applied, 4)
+	assert.Equal(t, []string{"POST libs-local", "PUT libs", "DELETE old", "DELETE old-local"}, f.requests)
+	assert.Equal(t, "libraries", f.repositories["libs-local"]["description"])
+
+	plan, err = r.Plan(ctx, desired, &PlanOptions{Delete: true})
+	assert.Nil(t, err)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, "no changes\n", plan.String())
+}
+
+func TestApplyFailure(t *testing.T) {
+	f := newFakeArtifactory()
+	r, closeServer := newTestReconciler(f)
+	defer closeServer()
+
+	plan := &Plan{Changes: []Change{
+		{Action: ActionCreate, Key: "libs-local", RClass: "local", Desired: mustParse(t, "{key: libs-local, rclass: local, packageType: maven}")[0]},
+		{Action: ActionDelete, Key: "other", RClass: "unknown"},
+	}}
+	applied, err := r.Apply(context.Background(), plan)
+	assert.EqualError(t, err, "delete unknown repository [other]: unsupported class [unknown]")
+	assert.Len(t, applied, 1)
+}
+
+func TestApplyConflict(t *testing.T) {
+	f := newFakeArtifactory(`{"key": "libs-local", "rclass": "local", "packageType": "maven", "description": "old"}`)
+	r, closeServer := newTestReconciler(f)
+	defer closeServer()
+	ctx := context.Background()
+
+	plan, err := r.Plan(ctx, mustParse(t, "{key: libs-local, rclass: local, packageType: maven, description: new}"), nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	f.repositories["libs-local"]["notes"] = "changed since the plan"
+
+	applied, err := r.Apply(ctx, plan)
+	assert.Empty(t, applied)
+	assert.Contains(t, err.Error(), "was changed concurrently")
+	assert.Empty(t, f.requests)
+}
+
+func TestApplyPassword(t *testing.T) {
+	f := newFakeArtifactory(`{"key": "maven-remote", "rclass": "remote", "packageType": "maven", "url": "https://repo1.maven.org/maven2", "username": "old"}`)
+	r, closeServer := newTestReconciler(f)
+	defer closeServer()
+	ctx := context.Background()
+
+	plan, err := r.Plan(ctx, mustParse(t, `
+key: maven-remote
+rclass: remote
+packageType: maven
+url: https://repo1.maven.org/maven2
+username: deployer
+password: secret
+`), nil)
+	if !assert.Nil(t, err) || !assert.Len(t, plan.Changes, 1) {
+		return
+	}
+	assert.Len(t, plan.Changes[0].Diffs, 2)
+
+	_, err = r.Apply(ctx, plan)
+	assert.Nil(t, err)
+	assert.Equal(t, "deployer", f.repositories["maven-remote"]["username"])
+	assert.Equal(t, "secret", f.repositories["maven-remote"]["password"])
+
+	// the password alone, as when it's rotated, can't be compared and is still applied
+	f.requests = nil
+	plan, err = r.Plan(ctx, mustParse(t, `
+key: maven-remote
+rclass: remote
+packageType: maven
+url: https://repo1.maven.org/maven2
+password: rotated
+`), nil)
+	if !assert.Nil(t, err) || !assert.Len(t, plan.Changes, 1) {
+		return
+	}
+	_, err = r.Apply(ctx, plan)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"POST maven-remote"}, f.requests)
+	assert.Equal(t, "rotated", f.repositories["maven-remote"]["password"])
+}
@@ -0,0 +1,141 @@
+// Package reconcile brings the repositories of an Artifactory instance to the state declared in YAML or JSON files:
+// it plans the repositories to create, update and delete, then applies the plan.
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/listspa/go-artifactory/v2/artifactory/v1"
+	"gopkg.in/yaml.v2"
+)
+
+// repositoryFile is the layout of a definition file holding a list of repositories under a key. A file may also hold
+// a single repository or a bare list of repositories.
+type repositoryFile struct {
+	Key          *string           `json:"key"` // Set when the file holds a single repository
+	Repositories []json.RawMessage `json:"repositories"`
+}
+
+// Load reads the desired repositories from the given files, and from the .yaml, .yml and .json files of the given
+// directories in lexical order. Each repository is declared with the attributes of the repository configuration
+// API, its key and rclass being mandatory. A repository declared twice is an error.
+func Load(paths ...string) ([]v1.Repository, error) {
+	files := make([]string, 0)
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		err = filepath.Walk(p, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && definitionFormat(file) != "" {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	repositories := make([]v1.Repository, 0)
+	declared := make(map[string]string)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		loaded, err := Parse(data, definitionFormat(file))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		for _, r := range loaded {
+			if previous, ok := declared[r.GetKey()]; ok {
+				return nil, fmt.Errorf("%s: repository [%s] already declared in %s", file, r.GetKey(), previous)
+			}
+			declared[r.GetKey()] = file
+			repositories = append(repositories, r)
+		}
+	}
+	return repositories, nil
+}
+
+func definitionFormat(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	}
+	return ""
+}
+
+// Parse decodes the repositories declared in a definition, in the yaml or json format
+func Parse(data []byte, format string) ([]v1.Repository, error) {
+	switch format {
+	case "json":
+	case "yaml":
+		var definition interface{}
+		if err := yaml.Unmarshal(data, &definition); err != nil {
+			return nil, err
+		}
+		var err error
+		if data, err = json.Marshal(yamlToJSON(definition)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported definition format [%s]", format)
+	}
+
+	raws := make([]json.RawMessage, 0)
+	file := new(repositoryFile)
+	if err := json.Unmarshal(data, file); err == nil && file.Key == nil && file.Repositories != nil {
+		raws = file.Repositories
+	} else if err := json.Unmarshal(data, client.NewOneOrMany(&raws)); err != nil {
+		return nil, err
+	}
+
+	repositories := make([]v1.Repository, 0, len(raws))
+	for i, raw := range raws {
+		r, err := v1.DecodeRepository(raw)
+		if err != nil {
+			return nil, fmt.Errorf("repository #%d: %v", i+1, err)
+		}
+		if r.GetKey() == "" {
+			return nil, fmt.Errorf("repository #%d: key is required", i+1)
+		}
+		repositories = append(repositories, r)
+	}
+	return repositories, nil
+}
+
+// yamlToJSON converts the maps decoded by yaml, whose keys may be of any type, to maps with string keys
+func yamlToJSON(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[fmt.Sprint(k)] = yamlToJSON(item)
+		}
+		return m
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = yamlToJSON(item)
+		}
+		return items
+	}
+	return v
+}
@@ -0,0 +1,75 @@
+package reconcile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	repositories, err := Parse([]byte(`
+repositories:
+  - key: libs-local
+    rclass: local
+    packageType: maven
+    handleSnapshots: false
+  - key: libs
+    rclass: virtual
+    packageType: maven
+    repositories: [libs-local]
+`), "yaml")
+	assert.Nil(t, err)
+	if assert.Len(t, repositories, 2) {
+		local, ok := v1.AsLocal(repositories[0])
+		if assert.True(t, ok) {
+			assert.False(t, *local.HandleSnapshots)
+		}
+		virtual, ok := v1.AsVirtual(repositories[1])
+		if assert.True(t, ok) {
+			assert.Equal(t, []string{"libs-local"}, *virtual.Repositories)
+		}
+	}
+
+	// a single virtual repository is not mistaken for a list of repositories
+	repositories, err = Parse([]byte(`{"key": "libs", "rclass": "virtual", "repositories": ["libs-local"]}`), "json")
+	assert.Nil(t, err)
+	assert.Len(t, repositories, 1)
+
+	repositories, err = Parse([]byte(`[{"key": "a", "rclass": "local"}, {"key": "b", "rclass": "remote"}]`), "json")
+	assert.Nil(t, err)
+	assert.Len(t, repositories, 2)
+
+	_, err = Parse([]byte(`{"rclass": "local"}`), "json")
+	assert.EqualError(t, err, "repository #1: key is required")
+	_, err = Parse([]byte(`{"key": "a", "rclass": "other"}`), "json")
+	assert.EqualError(t, err, "repository #1: unsupported class [other] of repository [a]")
+	_, err = Parse([]byte(`key = "a"`), "toml")
+	assert.NotNil(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reconcile")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	_ = os.Mkdir(filepath.Join(dir, "remote"), 0755)
+	_ = ioutil.WriteFile(filepath.Join(dir, "local.yml"), []byte("key: libs-local\nrclass: local\n"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(dir, "remote", "maven.json"), []byte(`{"key": "maven-remote", "rclass": "remote", "url": "https://repo1.maven.org/maven2"}`), 0644)
+	_ = ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("not a definition"), 0644)
+
+	repositories, err := Load(dir)
+	assert.Nil(t, err)
+	if assert.Len(t, repositories, 2) {
+		assert.Equal(t, "libs-local", repositories[0].GetKey())
+		assert.Equal(t, "maven-remote", repositories[1].GetKey())
+	}
+
+	_ = ioutil.WriteFile(filepath.Join(dir, "remote", "again.yaml"), []byte("key: libs-local\nrclass: local\n"), 0644)
+	_, err = Load(dir)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,301 @@
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/v1"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// FieldDiff is an attribute of a repository whose current value differs from the desired one
+type FieldDiff = v1.RepositoryFieldChange
+
+// Change is a repository to create, update or delete
+type Change struct {
+	Action  string // One of create|update|delete
+	Key     string
+	RClass  string
+	Desired v1.Repository // Nil for the deletions
+	Current v1.Repository // Nil for the creations
+	Diffs   []FieldDiff   // Updates only
+}
+
+// Plan lists the changes bringing the repositories to the desired state, in the order they are applied: the
+// creations and updates of the non virtual repositories, then of the virtual repositories, aggregated repositories
+// first, then the deletions of the virtual repositories, aggregating repositories first, and of the others.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty tells whether the repositories are already in the desired state
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p Plan) String() string {
+	if p.Empty() {
+		return "no changes\n"
+	}
+	buf := new(bytes.Buffer)
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			fmt.Fprintf(buf, "+ create %s repository [%s]\n", c.RClass, c.Key)
+		case ActionUpdate:
+			fmt.Fprintf(buf, "~ update %s repository [%s]\n", c.RClass, c.Key)
+			for _, d := range c.Diffs {
+				if d.WriteOnly {
+					// the value is a secret
+					fmt.Fprintf(buf, "    %s: write-only field, cannot be compared\n", d.Field)
+					continue
+				}
+				fmt.Fprintf(buf, "    %s: %s => %s\n", d.Field, formatValue(d.Current), formatValue(d.Desired))
+			}
+		case ActionDelete:
+			fmt.Fprintf(buf, "- delete %s repository [%s]\n", c.RClass, c.Key)
+		}
+	}
+	return buf.String()
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "(unset)"
+	}
+	res, _ := json.Marshal(v)
+	return string(res)
+}
+
+type PlanOptions struct {
+	// Deletes the existing repositories which are not desired
+	Delete bool
+	// Patterns, matched with path.Match, of the repositories never deleted. Default: none
+	KeepUnmanaged []string
+}
+
+// Reconciler plans and applies the changes to the repositories of an Artifactory instance
+type Reconciler struct {
+	repositories *v1.RepositoriesService
+}
+
+// NewReconciler returns a reconciler managing the repositories through the given service
+func NewReconciler(repositories *v1.RepositoriesService) *Reconciler {
+	return &Reconciler{repositories: repositories}
+}
+
+// Plan fetches the current configuration of the desired repositories and returns the changes bringing them to the
+// desired state. Only the attributes set on the desired repositories are compared, the others are left as they are.
+// The write only attributes, like the password, can't be compared: the repositories setting them are always updated.
+// The desired repositories are validated first: they must pass v1.ValidateRepository, keep their current rclass, and
+// the repositories aggregated by the virtual repositories must be desired, or exist and not be deleted.
+func (r *Reconciler) Plan(ctx context.Context, desired []v1.Repository, opt *PlanOptions) (*Plan, error) {
+	if opt == nil {
+		opt = new(PlanOptions)
+	}
+	details, _, err := r.repositories.ListRepositories(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]string)
+	for _, d := range *details {
+		if d.Key != nil && d.Type != nil {
+			existing[*d.Key] = strings.ToLower(*d.Type)
+		}
+	}
+
+	problems := &v1.ValidationError{Subject: "desired repositories"}
+	wanted := make(map[string]v1.Repository, len(desired))
+	for _, d := range desired {
+		if _, ok := wanted[d.GetKey()]; ok {
+			problems.Problems = append(problems.Problems, fmt.Sprintf("repository [%s] is declared twice", d.GetKey()))
+			continue
+		}
+		wanted[d.GetKey()] = d
+	}
+	for _, key := range sortedKeys(wanted) {
+		d := wanted[key]
+		if err := v1.ValidateRepository(d); err != nil {
+			problems.Problems = append(problems.Problems, err.Error())
+		}
+		if rclass, ok := existing[key]; ok && rclass != d.GetRClass() {
+			problems.Problems = append(problems.Problems, fmt.Sprintf("repository [%s] is %s, it can't become %s", key, rclass, d.GetRClass()))
+		}
+		virtual, ok := asVirtual(d)
+		if !ok || virtual.Repositories == nil {
+			continue
+		}
+		for _, member := range *virtual.Repositories {
+			if _, ok := wanted[member]; ok {
+				continue
+			}
+			if _, ok := existing[member]; !ok {
+				problems.Problems = append(problems.Problems, fmt.Sprintf("repository [%s] aggregates the unknown repository [%s]", key, member))
+			} else if opt.Delete && !matchesAny(member, opt.KeepUnmanaged) {
+				problems.Problems = append(problems.Problems, fmt.Sprintf("repository [%s] aggregates the repository [%s] to delete", key, member))
+			}
+		}
+	}
+	if len(problems.Problems) > 0 {
+		return nil, problems
+	}
+
+	// creations and updates
+	upserts := make(map[string]Change)
+	for _, key := range sortedKeys(wanted) {
+		d := wanted[key]
+		if _, ok := existing[key]; !ok {
+			upserts[key] = Change{Action: ActionCreate, Key: key, RClass: d.GetRClass(), Desired: d}
+			continue
+		}
+		current, _, err := r.repositories.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		diffs, err := Diff(current, d)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) > 0 {
+			upserts[key] = Change{Action: ActionUpdate, Key: key, RClass: d.GetRClass(), Desired: d, Current: current, Diffs: diffs}
+		}
+	}
+
+	// deletions
+	deletes := make(map[string]v1.Repository)
+	if opt.Delete {
+		keys := make([]string, 0, len(existing))
+		for key := range existing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, ok := wanted[key]; ok || matchesAny(key, opt.KeepUnmanaged) {
+				continue
+			}
+			current, _, err := r.repositories.Get(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			deletes[key] = current
+		}
+	}
+
+	plan := &Plan{Changes: make([]Change, 0, len(upserts)+len(deletes))}
+	virtuals := make(map[string]v1.Repository)
+	for _, key := range sortedKeys(wanted) {
+		if wanted[key].GetRClass() == v1.RepositoryClassVirtual {
+			virtuals[key] = wanted[key]
+		} else if c, ok := upserts[key]; ok {
+			plan.Changes = append(plan.Changes, c)
+		}
+	}
+	ordered, err := aggregatedFirst(virtuals)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range ordered {
+		if c, ok := upserts[key]; ok {
+			plan.Changes = append(plan.Changes, c)
+		}
+	}
+
+	virtuals = make(map[string]v1.Repository)
+	for key, current := range deletes {
+		if current.GetRClass() == v1.RepositoryClassVirtual {
+			virtuals[key] = current
+		}
+	}
+	ordered, err = aggregatedFirst(virtuals)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(ordered) - 1; i >= 0; i-- {
+		plan.Changes = append(plan.Changes, deletion(deletes[ordered[i]]))
+	}
+	for _, key := range sortedKeys(deletes) {
+		if deletes[key].GetRClass() != v1.RepositoryClassVirtual {
+			plan.Changes = append(plan.Changes, deletion(deletes[key]))
+		}
+	}
+	return plan, nil
+}
+
+func deletion(current v1.Repository) Change {
+	return Change{Action: ActionDelete, Key: current.GetKey(), RClass: current.GetRClass(), Current: current}
+}
+
+// Diff compares the attributes set on the desired configuration of a repository with the current ones, and returns
+// the differences sorted by attribute, see v1.DiffRepository
+func Diff(current v1.Repository, desired v1.Repository) ([]FieldDiff, error) {
+	return v1.DiffRepository(current, desired)
+}
+
+// aggregatedFirst sorts the virtual repositories so that each one comes after the virtual repositories it aggregates
+func aggregatedFirst(virtuals map[string]v1.Repository) ([]string, error) {
+	ordered := make([]string, 0, len(virtuals))
+	done := make(map[string]bool)
+	visiting := make(map[string]bool)
+	var visit func(key string) error
+	visit = func(key string) error {
+		if done[key] {
+			return nil
+		}
+		if visiting[key] {
+			return fmt.Errorf("virtual repository [%s] aggregates itself", key)
+		}
+		visiting[key] = true
+		if virtual, ok := asVirtual(virtuals[key]); ok && virtual.Repositories != nil {
+			for _, member := range *virtual.Repositories {
+				if _, ok := virtuals[member]; ok {
+					if err := visit(member); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		visiting[key] = false
+		done[key] = true
+		ordered = append(ordered, key)
+		return nil
+	}
+	for _, key := range sortedKeys(virtuals) {
+		if err := visit(key); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func asVirtual(r v1.Repository) (*v1.VirtualRepository, bool) {
+	return v1.AsVirtual(pointerTo(r))
+}
+
+func matchesAny(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of the repositories, sorted
+func sortedKeys(repositories map[string]v1.Repository) []string {
+	keys := make([]string, 0, len(repositories))
+	for k := range repositories {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,205 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/listspa/go-artifactory/v2/artifactory/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeArtifactory serves the repository configuration API from the repositories it holds, and records the requests
+// changing them
+type fakeArtifactory struct {
+	mu           sync.Mutex
+	repositories map[string]map[string]interface{}
+	requests     []string
+}
+
+func newFakeArtifactory(repositories ...string) *fakeArtifactory {
+	f := &fakeArtifactory{repositories: make(map[string]map[string]interface{})}
+	for _, r := range repositories {
+		config := make(map[string]interface{})
+		_ = json.Unmarshal([]byte(r), &config)
+		f.repositories[config["key"].(string)] = config
+	}
+	return f
+}
+
+func (f *fakeArtifactory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/repositories"), "/")
+	if r.Method != "GET" {
+		f.requests = append(f.requests, r.Method+" "+key)
+	}
+	switch {
+	case r.Method == "GET" && key == "":
+		list := make([]v1.RepositoryDetails, 0)
+		for k, config := range f.repositories {
+			list = append(list, v1.RepositoryDetails{Key: v1.String(k), Type: v1.String(strings.ToUpper(config["rclass"].(string)))})
+		}
+		sort.Slice(list, func(i, j int) bool { return *list[i].Key < *list[j].Key })
+		_ = json.NewEncoder(w).Encode(list)
+	case r.Method == "GET":
+		if config, ok := f.repositories[key]; ok {
+			_ = json.NewEncoder(w).Encode(config)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == "PUT" || r.Method == "POST":
+		config := f.repositories[key]
+		if config == nil {
+			config = make(map[string]interface{})
+			f.repositories[key] = config
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		changes := make(map[string]interface{})
+		_ = json.Unmarshal(body, &changes)
+		for k, v := range changes {
+			config[k] = v
+		}
+	case r.Method == "DELETE":
+		delete(f.repositories, key)
+	}
+}
+
+func newTestReconciler(f *fakeArtifactory) (*Reconciler, func()) {
+	server := httptest.NewServer(f)
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	return NewReconciler(v1.NewV1(c).Repositories), server.Close
+}
+
+func mustParse(t *testing.T, definition string) []v1.Repository {
+	repositories, err := Parse([]byte(definition), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repositories
+}
+
+func planActions(plan *Plan) []string {
+	actions := make([]string, 0, len(plan.Changes))
+	for _, c := range plan.Changes {
+		actions = append(actions, fmt.Sprintf("%s %s", c.Action, c.Key))
+	}
+	return actions
+}
+
+func TestPlan(t *testing.T) {
+	f := newFakeArtifactory(
+		`{"key": "libs-local", "rclass": "local", "packageType": "maven", "description": "old", "handleSnapshots": true}`,
+		`{"key": "maven-remote", "rclass": "remote", "packageType": "maven", "url": "https://repo1.maven.org/maven2"}`,
+		`{"key": "old-local", "rclass": "local", "packageType": "generic"}`,
+		`{"key": "old", "rclass": "virtual", "packageType": "generic", "repositories": ["old-all"]}`,
+		`{"key": "old-all", "rclass": "virtual", "packageType": "generic", "repositories": ["old-local"]}`,
+		`{"key": "tmp-local", "rclass": "local", "packageType": "generic"}`,
+	)
+	r, closeServer := newTestReconciler(f)
+	defer closeServer()
+
+	desired := mustParse(t, `
+- key: libs
+  rclass: virtual
+  packageType: maven
+  repositories: [libs-release, libs-local, maven-remote]
+- key: libs-release
+  rclass: virtual
+  packageType: maven
+  repositories: [libs-local]
+- key: libs-local
+  rclass: local
+  packageType: maven
+  description: libraries
+  handleSnapshots: true
+- key: maven-remote
+  rclass: remote
+  packageType: maven
+  url: https://repo1.maven.org/maven2
+  password: secret
+- key: npm-local
+  rclass: local
+  packageType: npm
+`)
+	plan, err := r.Plan(context.Background(), desired, &PlanOptions{Delete: true, KeepUnmanaged: []string{"tmp-*"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"update libs-local",
+		"update maven-remote",
+		"create npm-local",
+		"create libs-release",
+		"create libs",
+		"delete old",
+		"delete old-all",
+		"delete old-local",
+	}, planActions(plan))
+	assert.Equal(t, []FieldDiff{{Field: "description", Current: "old", Desired: "libraries"}}, plan.Changes[0].Diffs)
+	assert.Contains(t, plan.String(), "~ update local repository [libs-local]\n    description: \"old\" => \"libraries\"\n")
+	assert.Equal(t, []FieldDiff{{Field: "password", Desired: "secret", WriteOnly: true}}, plan.Changes[1].Diffs)
+	assert.Contains(t, plan.String(), "~ update remote repository [maven-remote]\n    password: write-only field, cannot be compared\n")
+	assert.NotContains(t, plan.String(), "secret")
+
+	plan, err = r.Plan(context.Background(), desired, nil)
+	assert.Nil(t, err)
+	assert.Len(t, plan.Changes, 5)
+	assert.Empty(t, f.requests)
+}
+
+func TestPlanValidation(t *testing.T) {
+	f := newFakeArtifactory(
+		`{"key": "libs-local", "rclass": "local", "packageType": "maven"}`,
+		`{"key": "old-local", "rclass": "local", "packageType": "maven"}`,
+	)
+	r, closeServer := newTestReconciler(f)
+	defer closeServer()
+
+	_, err := r.Plan(context.Background(), mustParse(t, `
+- key: libs-local
+  rclass: remote
+  packageType: maven
+  url: https://repo1.maven.org/maven2
+- key: libs
+  rclass: virtual
+  packageType: maven
+  repositories: [old-local, missing]
+`), &PlanOptions{Delete: true})
+	if assert.IsType(t, &v1.ValidationError{}, err) {
+		assert.Equal(t, []string{
+			"repository [libs] aggregates the repository [old-local] to delete",
+			"repository [libs] aggregates the unknown repository [missing]",
+			"repository [libs-local] is local, it can't become remote",
+		}, err.(*v1.ValidationError).Problems)
+	}
+
+	_, err = r.Plan(context.Background(), mustParse(t, `
+- key: a
+  rclass: virtual
+  packageType: generic
+  repositories: [b]
+- key: b
+  rclass: virtual
+  packageType: generic
+  repositories: [a]
+`), nil)
+	assert.NotNil(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	current := &v1.RemoteRepository{Key: v1.String("remote"), Url: v1.String("https://a"), Offline: v1.Bool(false)}
+	desired := &v1.RemoteRepository{Key: v1.String("remote"), Url: v1.String("https://b"), Description: v1.String("remote")}
+	diffs, err := Diff(current, desired)
+	assert.Nil(t, err)
+	assert.Equal(t, []FieldDiff{
+		{Field: "description", Current: nil, Desired: "remote"},
+		{Field: "url", Current: "https://a", Desired: "https://b"},
+	}, diffs)
+}
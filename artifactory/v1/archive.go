@@ -0,0 +1,292 @@
+package v1
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// archiveEntrySeparator separates the path of an archive from the path of an entry inside it
+const archiveEntrySeparator = "!/"
+
+// ArchiveEntry is a file or a folder inside an archive artifact
+type ArchiveEntry struct {
+	Path   *string `json:"path,omitempty"` // Path of the entry inside the archive, without leading slash
+	Folder *bool   `json:"folder,omitempty"`
+}
+
+type ArchiveEntriesOptions struct {
+	Path      string // Optional folder inside the archive to list. Default: the archive root
+	Recursive bool   // Also list the content of the sub folders
+}
+
+func archiveEntryPath(archivePath string, entryPath string) string {
+	return fmt.Sprintf("%s%s%s", strings.TrimSuffix(archivePath, "/"), archiveEntrySeparator, strings.TrimPrefix(entryPath, "/"))
+}
+
+// ListArchiveEntries lists the entries of an archive (zip, jar, war, tar...) through the storage API, without
+// downloading the archive.
+// Notes: Requires archive browsing to be enabled on the repository (ArchiveBrowsingEnabled)
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) ListArchiveEntries(ctx context.Context, repoKey string, archivePath string, opt *ArchiveEntriesOptions) ([]ArchiveEntry, *http.Response, error) {
+	if opt == nil {
+		opt = &ArchiveEntriesOptions{}
+	}
+
+	entries := make([]ArchiveEntry, 0)
+	folders := []string{strings.Trim(opt.Path, "/")}
+	var resp *http.Response
+	for len(folders) > 0 {
+		folder := folders[0]
+		folders = folders[1:]
+
+		// the url is not built with NewRequest, which would clean the trailing slash of the archive root
+		targetURL := fmt.Sprintf("%sapi/storage/%s/%s", s.client.BaseURL.String(), repoKey, archiveEntryPath(archivePath, folder))
+		req, err := http.NewRequest("GET", targetURL, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating new request: %v", err)
+		}
+		var info *FolderInfo
+		info, resp, err = s.folderInfo(ctx, req)
+		if err != nil {
+			return nil, resp, err
+		}
+		if info.Children == nil {
+			continue
+		}
+		for _, child := range *info.Children {
+			if child.Uri == nil {
+				continue
+			}
+			entryPath := strings.Trim(*child.Uri, "/")
+			if folder != "" {
+				entryPath = folder + "/" + entryPath
+			}
+			isFolder := child.Folder != nil && *child.Folder
+			entries = append(entries, ArchiveEntry{Path: String(entryPath), Folder: &isFolder})
+			if isFolder && opt.Recursive {
+				folders = append(folders, entryPath)
+			}
+		}
+	}
+	return entries, resp, nil
+}
+
+// DownloadArchiveEntry copies a single entry of an archive to the given target, without downloading the archive.
+// Notes: Requires archive browsing to be enabled on the repository (ArchiveBrowsingEnabled)
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) DownloadArchiveEntry(ctx context.Context, repoKey string, archivePath string, entryPath string, file io.Writer) (*http.Response, error) {
+	if file == nil {
+		return nil, fmt.Errorf("target is not allowed to be nil")
+	}
+	log.Debugf("[Artifactory Client] Downloading entry [%s] of [%s/%s]", entryPath, repoKey, archivePath)
+	// entries have no checksum of their own, hence they are never served by the download cache
+	return s.download(ctx, repoKey, archiveEntryPath(archivePath, entryPath), file)
+}
+
+const (
+	ArchiveTypeZip   = "zip"
+	ArchiveTypeTar   = "tar"
+	ArchiveTypeTarGz = "tar.gz"
+	ArchiveTypeTgz   = "tgz"
+)
+
+type FolderArchiveOptions struct {
+	ArchiveType          string `url:"archiveType,omitempty"`          // One of zip|tar|tar.gz|tgz. Default: zip
+	IncludeChecksumFiles bool   `url:"includeChecksumFiles,omitempty"` // Adds a .md5, .sha1 and .sha256 file for each artifact
+}
+
+func (o *FolderArchiveOptions) archiveType() string {
+	if o == nil || o.ArchiveType == "" {
+		return ArchiveTypeZip
+	}
+	return o.ArchiveType
+}
+
+// DownloadFolderArchive streams the content of a repository folder to the given target as an archive generated by
+// Artifactory.
+// Since: 4.1.0
+// Notes: Requires Artifactory Pro. Folder download must be enabled in the general configuration.
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) DownloadFolderArchive(ctx context.Context, repoKey string, folderPath string, opt *FolderArchiveOptions, file io.Writer) (*http.Response, error) {
+	if file == nil {
+		return nil, fmt.Errorf("target is not allowed to be nil")
+	}
+
+	archiveType := opt.archiveType()
+	switch archiveType {
+	case ArchiveTypeZip, ArchiveTypeTar, ArchiveTypeTarGz, ArchiveTypeTgz:
+	default:
+		return nil, fmt.Errorf("unsupported archive type [%s]", archiveType)
+	}
+
+	query := FolderArchiveOptions{ArchiveType: archiveType}
+	if opt != nil {
+		query.IncludeChecksumFiles = opt.IncludeChecksumFiles
+	}
+	path, err := client.AddOptions(fmt.Sprintf("/api/archive/download/%s/%s", repoKey, folderPath), query)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating new request: %v", err)
+	}
+	log.Debugf("[Artifactory Client] Archive download API [%s]", req.URL.String())
+	if tracker := s.newProgressTracker(TransferDownload, repoKey, folderPath, -1); tracker != nil {
+		defer tracker.done()
+		file = &progressWriter{Writer: file, tracker: tracker}
+	}
+	return s.client.Do(ctx, req, file)
+}
+
+// ExtractFolderArchive downloads a repository folder as an archive and extracts it in the destination directory. Tar
+// archives are extracted while they are downloaded, zip ones once the download is complete as their index is at the end.
+func (s *ArtifactService) ExtractFolderArchive(ctx context.Context, repoKey string, folderPath string, opt *FolderArchiveOptions, destDir string) (*http.Response, error) {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "creating destination [%s]", destDir)
+	}
+
+	if opt.archiveType() == ArchiveTypeZip {
+		tmp, err := ioutil.TempFile("", "artifactory-archive-")
+		if err != nil {
+			return nil, errors.Wrap(err, "creating temporary file")
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		resp, err := s.DownloadFolderArchive(ctx, repoKey, folderPath, opt, tmp)
+		if err != nil {
+			return resp, err
+		}
+		size, err := tmp.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return resp, err
+		}
+		return resp, extractZip(tmp, size, destDir)
+	}
+
+	pr, pw := io.Pipe()
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := s.DownloadFolderArchive(ctx, repoKey, folderPath, opt, pw)
+		pw.CloseWithError(err)
+		done <- result{resp: resp, err: err}
+	}()
+
+	extractErr := extractTar(pr, opt.archiveType() != ArchiveTypeTar, destDir)
+	// unblocks the download if the extraction stopped before the end of the archive
+	pr.CloseWithError(extractErr)
+	r := <-done
+	if r.err != nil {
+		return r.resp, r.err
+	}
+	return r.resp, extractErr
+}
+
+// extractPath returns the destination of an archive entry, rejecting entries escaping the destination directory
+func extractPath(destDir string, name string) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+	rel, err := filepath.Rel(destDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("illegal archive entry [%s]", name)
+	}
+	return target, nil
+}
+
+func extractFile(target string, mode os.FileMode, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func extractTar(r io.Reader, gzipped bool, destDir string) error {
+	if gzipped {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return errors.Wrap(err, "reading gzip archive")
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "reading tar archive")
+		}
+
+		target, err := extractPath(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			err = extractFile(target, os.FileMode(header.Mode), tr)
+		default:
+			log.Debugf("[Artifactory Client] skipping archive entry [%s] of type %c", header.Name, header.Typeflag)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "extracting [%s]", header.Name)
+		}
+	}
+}
+
+func extractZip(r io.ReaderAt, size int64, destDir string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return errors.Wrap(err, "reading zip archive")
+	}
+	for _, f := range zr.File {
+		target, err := extractPath(destDir, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return errors.Wrapf(err, "extracting [%s]", f.Name)
+			}
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return errors.Wrapf(err, "extracting [%s]", f.Name)
+		}
+		err = extractFile(target, f.Mode(), rc)
+		rc.Close()
+		if err != nil {
+			return errors.Wrapf(err, "extracting [%s]", f.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListArchiveEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.RequestURI {
+		case "/api/storage/libs-release/com/example/lib-1.0.jar!/":
+			_, _ = fmt.Fprint(w, `{"children": [{"uri": "/META-INF", "folder": true}, {"uri": "/lib.properties", "folder": false}]}`)
+		case "/api/storage/libs-release/com/example/lib-1.0.jar!/META-INF":
+			_, _ = fmt.Fprint(w, `{"children": [{"uri": "/MANIFEST.MF", "folder": false}]}`)
+		default:
+			t.Errorf("unexpected request [%s]", r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	entries, _, err := v.Artifacts.ListArchiveEntries(context.Background(), "libs-release", "com/example/lib-1.0.jar", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "META-INF", *entries[0].Path)
+	assert.True(t, *entries[0].Folder)
+
+	entries, _, err = v.Artifacts.ListArchiveEntries(context.Background(), "libs-release", "com/example/lib-1.0.jar", &ArchiveEntriesOptions{Recursive: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "META-INF/MANIFEST.MF", *entries[2].Path)
+	assert.False(t, *entries[2].Folder)
+}
+
+func TestDownloadArchiveEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/libs-release/com/example/lib-1.0.jar!/META-INF/MANIFEST.MF", r.RequestURI)
+		_, _ = fmt.Fprint(w, "Manifest-Version: 1.0")
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	target := new(bytes.Buffer)
+	_, err := v.Artifacts.DownloadArchiveEntry(context.Background(), "libs-release", "com/example/lib-1.0.jar", "/META-INF/MANIFEST.MF", target)
+	assert.Nil(t, err)
+	assert.Equal(t, "Manifest-Version: 1.0", target.String())
+}
+
+func TestExtractFolderArchive(t *testing.T) {
+	tarGz := new(bytes.Buffer)
+	gz := gzip.NewWriter(tarGz)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "sub/", Typeflag: tar.TypeDir, Mode: 0755})
+	_ = tw.WriteHeader(&tar.Header{Name: "sub/file.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 7})
+	_, _ = tw.Write([]byte("content"))
+	_ = tw.Close()
+	_ = gz.Close()
+
+	zipped := new(bytes.Buffer)
+	zw := zip.NewWriter(zipped)
+	f, _ := zw.Create("sub/file.txt")
+	_, _ = f.Write([]byte("content"))
+	_ = zw.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/archive/download/generic-local/path/folder", r.URL.Path)
+		switch r.URL.Query().Get("archiveType") {
+		case "tar.gz":
+			assert.Equal(t, "true", r.URL.Query().Get("includeChecksumFiles"))
+			_, _ = w.Write(tarGz.Bytes())
+		case "zip":
+			_, _ = w.Write(zipped.Bytes())
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	for _, opt := range []*FolderArchiveOptions{{ArchiveType: ArchiveTypeTarGz, IncludeChecksumFiles: true}, nil} {
+		dir, err := ioutil.TempDir("", "artifactory-extract-")
+		assert.Nil(t, err)
+		_, err = v.Artifacts.ExtractFolderArchive(context.Background(), "generic-local", "path/folder", opt, dir)
+		assert.Nil(t, err)
+		content, err := ioutil.ReadFile(filepath.Join(dir, "sub", "file.txt"))
+		assert.Nil(t, err)
+		assert.Equal(t, "content", string(content))
+		os.RemoveAll(dir)
+	}
+
+	target := new(bytes.Buffer)
+	_, err := v.Artifacts.DownloadFolderArchive(context.Background(), "generic-local", "path/folder", nil, target)
+	assert.Nil(t, err)
+	assert.Equal(t, zipped.Bytes(), target.Bytes())
+
+	_, err = v.Artifacts.DownloadFolderArchive(context.Background(), "generic-local", "path/folder", &FolderArchiveOptions{ArchiveType: "rar"}, target)
+	assert.NotNil(t, err)
+}
+
+func TestExtractPath(t *testing.T) {
+	_, err := extractPath("/tmp/dest", "../escape.txt")
+	assert.NotNil(t, err)
+	_, err = extractPath("/tmp/dest", "sub/../../escape.txt")
+	assert.NotNil(t, err)
+	target, err := extractPath("/tmp/dest", "sub/file.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join("/tmp/dest", "sub", "file.txt"), target)
+}
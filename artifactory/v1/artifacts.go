@@ -3,8 +3,6 @@ package v1
 import (
 	"bytes"
 	"context"
-	"crypto/md5"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,14 +10,27 @@ import (
 	log "github.com/sirupsen/logrus"
 	"net/http"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
 	"github.com/pkg/errors"
 )
 
 //var searchTemplate = `items.find({"repo": "%s","path": {"$ne": "."},"$or": [{"$and":[{"path": {"$match": "*"},"name": {"$match": "%s"}}]}]}).include("name","repo","path","actual_md5","actual_sha1","size","type","property")`
 
-// ArtifactService exposes the Artifact API endpoints from Artifactory
-type ArtifactService Service
+// ArtifactService exposes the Artifact API endpoints from Artifactory. Unlike the other services, it holds the
+// optional behaviours of the artifact transfers.
+type ArtifactService struct {
+	Service
+
+	// Optional behaviours of the artifact transfers, guarded by mu
+	mu       sync.RWMutex
+	progress ProgressObserver
+	cache    *DownloadCache
+	recorder *BuildInfoRecorder
+}
 
 // SingleReplicationConfig is the model of the Artifactory Replication Config
 type SingleReplicationConfig struct {
@@ -104,8 +115,8 @@ func (s *ArtifactService) GetRepositoryReplicationConfig(ctx context.Context, re
 }
 
 // Gets the replication configs for a given repository key.
-// Note: As the get endpoint can return a single object or an array (if there is more than one replication), extra work
-// is required to marshall the response into an expected, consistent format.
+// Note: As the get endpoint can return a single object or an array (if there is more than one replication), the
+// response is decoded with client.OneOrMany into a consistent format.
 func (s *ArtifactService) getReplicationConfigs(ctx context.Context, repoKey string) ([]SingleReplicationConfig, *http.Response, error) {
 	path := fmt.Sprintf("/api/replications/%s", repoKey)
 	req, err := s.client.NewRequest("GET", path, nil)
@@ -114,44 +125,12 @@ func (s *ArtifactService) getReplicationConfigs(ctx context.Context, repoKey str
 	}
 	req.Header.Set("Accept", mediaTypeReplicationConfig)
 
-	// By writing the response to a buffer, we can evaluate the type and decode appropriately.
-	var httpBody bytes.Buffer
-	resp, err := s.client.Do(ctx, req, &httpBody)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	// A copy is required as the initial write to the httpBody buffer contains EOF issues.
-	var httpBodyCopy bytes.Buffer
-	_, err = io.Copy(&httpBodyCopy, &httpBody)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	var repConfigAsInterface interface{}
-	err = json.Unmarshal(httpBodyCopy.Bytes(), &repConfigAsInterface)
+	replications := make([]SingleReplicationConfig, 0)
+	resp, err := s.client.Do(ctx, req, client.NewOneOrMany(&replications))
 	if err != nil {
 		return nil, resp, err
 	}
 
-	// Checks to see what type of response is returned, and then casts to that.
-	replications := make([]SingleReplicationConfig, 0)
-	switch repConfigAsInterface.(type) {
-	case []interface{}:
-		err = json.NewDecoder(&httpBodyCopy).Decode(&replications)
-		if err != nil {
-			return nil, resp, err
-		}
-	default:
-		singleReplication := new(SingleReplicationConfig)
-		err = json.NewDecoder(&httpBodyCopy).Decode(singleReplication)
-		if err != nil {
-			return nil, resp, err
-		}
-
-		replications = append(replications, *singleReplication)
-	}
-
 	return replications, resp, nil
 }
 
@@ -216,6 +195,23 @@ type FileInfo struct {
 	Uri               *string    `json:"uri,omitempty"`
 }
 
+type FolderChild struct {
+	Uri    *string `json:"uri,omitempty"`
+	Folder *bool   `json:"folder,omitempty"`
+}
+
+type FolderInfo struct {
+	Repo         *string        `json:"repo,omitempty"`
+	Path         *string        `json:"path,omitempty"`
+	Created      *string        `json:"created,omitempty"`
+	CreatedBy    *string        `json:"createdBy,omitempty"`
+	LastModified *string        `json:"lastModified,omitempty"`
+	ModifiedBy   *string        `json:"modifiedBy,omitempty"`
+	LastUpdated  *string        `json:"lastUpdated,omitempty"`
+	Children     *[]FolderChild `json:"children,omitempty"`
+	Uri          *string        `json:"uri,omitempty"`
+}
+
 type AqlSearchResults struct {
 	Results []AqlResult `json:"results,omitempty"`
 }
@@ -253,57 +249,111 @@ func (s *ArtifactService) FileInfo(ctx context.Context, repoKey string, filePath
 	return fileInfo, resp, err
 }
 
+// FolderInfo Returns the metadata and the children of the given folder. Supported by local, local-cached and virtual repositories.
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) FolderInfo(ctx context.Context, repoKey string, folderPath string) (*FolderInfo, *http.Response, error) {
+	path := fmt.Sprintf("/api/storage/%s/%s", repoKey, folderPath)
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating new request: %v", err)
+	}
+	return s.folderInfo(ctx, req)
+}
+
+func (s *ArtifactService) folderInfo(ctx context.Context, req *http.Request) (*FolderInfo, *http.Response, error) {
+	req.Header.Set("Accept", mediaTypeFolderInfo)
+	log.Debugf("[Artifactory Client] Storage API [%s]", req.URL.String())
+	folderInfo := new(FolderInfo)
+	resp, err := s.client.Do(ctx, req, folderInfo)
+	return folderInfo, resp, err
+}
+
 // DownloadFileContents Copies the specified file to the given target. Supported by local, local-cached and virtual repositories.
+// When a download cache is set, the file is served from the cache if its checksum is unchanged. When a build info
+// recorder is set, the file is recorded as a dependency of the build.
 // Security: Requires a privileged user (can be anonymous)
 func (s *ArtifactService) DownloadFileContents(ctx context.Context, repoKey string, filePath string, file io.Writer) (*http.Response, error) {
 	if file == nil {
 		return nil, fmt.Errorf("target is not allowed to be nil")
 	}
 
+	recorder := s.buildInfoRecorder()
+	if recorder == nil {
+		return s.downloadFileContents(ctx, repoKey, filePath, file)
+	}
+	w := newChecksumWriter(file)
+	resp, err := s.downloadFileContents(ctx, repoKey, filePath, w)
+	if err == nil {
+		recorder.AddDependency(repoKey, filePath, w.checksums())
+	}
+	return resp, err
+}
+
+// downloadFileContents downloads the file through the cache, if set, without recording it in the build info
+func (s *ArtifactService) downloadFileContents(ctx context.Context, repoKey string, filePath string, file io.Writer) (*http.Response, error) {
+	if cache := s.downloadCache(); cache != nil {
+		cached, resp, err := s.cachedDownload(ctx, cache, repoKey, filePath, file)
+		if cached || err != nil {
+			return resp, err
+		}
+	}
+	return s.download(ctx, repoKey, filePath, file)
+}
+
+func (s *ArtifactService) download(ctx context.Context, repoKey string, filePath string, file io.Writer) (*http.Response, error) {
 	targetURL := fmt.Sprintf("%s%s/%s", s.client.BaseURL.String(), repoKey, filePath)
 	req, err := http.NewRequest("GET", targetURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating new request: %v", err)
 	}
 	log.Debugf("[Artifactory Client] Downloading API [%s]", req.URL.String())
+	if tracker := s.newProgressTracker(TransferDownload, repoKey, filePath, -1); tracker != nil {
+		defer tracker.done()
+		file = &progressWriter{Writer: file, tracker: tracker}
+	}
 	resp, err := s.client.Do(ctx, req, file)
 	return resp, err
 
 }
 
-// UploadFileContents Copies the specified file to the given target in Artifactory
+// UploadFileContents Copies the specified file to the given target in Artifactory. When a build info recorder is set,
+// the file is recorded as an artifact of the build.
 func (s *ArtifactService) UploadFileContents(ctx context.Context, repoKey string, filePath string, mimetype string, localfile string, props []ArtifactoryProperty) (*http.Response, error) {
-	var err error
-	var req *http.Request
-
-	targetURL := fmt.Sprintf("%s%s/%s", s.client.BaseURL.String(), repoKey, filePath)
-	for _, p := range props {
-		targetURL = fmt.Sprintf("%s;%s=%s", targetURL, p.Name, p.Value)
-	}
 	//content
 	content, err := ioutil.ReadFile(localfile)
 	if err != nil {
 		return nil, errors.Wrapf(err, "reading file content [%s]", filePath)
 	}
 
-	//md5sum
-	file, err := os.Open(localfile)
-	if err != nil {
-		return nil, errors.Wrapf(err, "opening file [%s]", filePath)
+	resp, err := s.uploadContents(ctx, repoKey, filePath, mimetype, content, props)
+	if recorder := s.buildInfoRecorder(); recorder != nil && err == nil {
+		recorder.AddArtifact(repoKey, filePath, computeChecksums(content))
 	}
-	defer file.Close()
-	hash := md5.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return nil, errors.Wrapf(err, "reading file m5dsum [%s]", filePath)
+	return resp, err
+}
+
+// uploadContents deploys the given content sending its md5, sha1 and sha256 checksums along, so that Artifactory can
+// verify the upload and serve the checksums to clients
+func (s *ArtifactService) uploadContents(ctx context.Context, repoKey string, filePath string, mimetype string, content []byte, props []ArtifactoryProperty) (*http.Response, error) {
+	targetURL := fmt.Sprintf("%s%s/%s", s.client.BaseURL.String(), repoKey, filePath)
+	for _, p := range props {
+		targetURL = fmt.Sprintf("%s;%s=%s", targetURL, p.Name, p.Value)
 	}
-	hashInBytes := hash.Sum(nil)[:16]
 
-	req, err = http.NewRequest("PUT", targetURL, bytes.NewBuffer(content))
+	checksums := computeChecksums(content)
+	req, err := http.NewRequest("PUT", targetURL, bytes.NewBuffer(content))
 	if err != nil {
 		return nil, fmt.Errorf("creating new request: %v", err)
 	}
+	if tracker := s.newProgressTracker(TransferUpload, repoKey, filePath, int64(len(content))); tracker != nil {
+		defer tracker.done()
+		// GetBody is left untouched, so that transports reading the body a second time aren't tracked
+		req.Body = ioutil.NopCloser(&progressReader{Reader: bytes.NewReader(content), tracker: tracker})
+	}
 	req.Header.Set("Content-Type", mimetype)
-	req.Header.Set("X-Checksum-MD5", hex.EncodeToString(hashInBytes))
+	req.Header.Set("X-Checksum-MD5", *checksums.Md5)
+	req.Header.Set("X-Checksum-Sha1", *checksums.Sha1)
+	req.Header.Set("X-Checksum-Sha256", *checksums.Sha256)
 	log.Debugf("[Artifactory Client] Uploading API [%s]", req.URL.String())
 	resp, err := s.client.Do(ctx, req, nil)
 	return resp, err
@@ -325,3 +375,162 @@ func (s *ArtifactService) SearchByAQL(ctx context.Context, query string) (*AqlSe
 
 	return aqlresults, resp, err
 }
+
+// ItemLocation identifies an item by its repository and its path in the repository
+type ItemLocation struct {
+	Repo *string `json:"repo,omitempty"`
+	Path *string `json:"path,omitempty"`
+}
+
+type ChecksumSearchOptions struct {
+	Md5    string   `url:"md5,omitempty"`
+	Sha1   string   `url:"sha1,omitempty"`
+	Sha256 string   `url:"sha256,omitempty"`
+	Repos  []string `url:"repos,comma,omitempty"` // Optional, limits the search to the given repositories
+}
+
+type ChecksumSearchResults struct {
+	Results []ChecksumSearchResult `json:"results,omitempty"`
+}
+
+type ChecksumSearchResult struct {
+	Uri *string `json:"uri,omitempty"`
+}
+
+// Location returns the repository and the path of the artifact found, extracted from its storage uri
+func (r ChecksumSearchResult) Location() (string, string, error) {
+	if r.Uri == nil {
+		return "", "", fmt.Errorf("search result without uri")
+	}
+	const storage = "/api/storage/"
+	i := strings.Index(*r.Uri, storage)
+	if i < 0 {
+		return "", "", fmt.Errorf("unexpected search result uri [%s]", *r.Uri)
+	}
+	parts := strings.SplitN((*r.Uri)[i+len(storage):], "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected search result uri [%s]", *r.Uri)
+	}
+	return parts[0], parts[1], nil
+}
+
+// SearchByChecksum finds all the artifacts with the given checksum, in the repositories the caller can read.
+// Since: 2.3.0 (sha256 since 5.5)
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) SearchByChecksum(ctx context.Context, opt *ChecksumSearchOptions) (*ChecksumSearchResults, *http.Response, error) {
+	path, err := client.AddOptions("/api/search/checksum", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating new request: %v", err)
+	}
+	req.Header.Set("Accept", client.MediaTypeJson)
+	log.Debugf("[Artifactory Client] Checksum search API [%s]", req.URL.String())
+
+	results := new(ChecksumSearchResults)
+	resp, err := s.client.Do(ctx, req, results)
+	return results, resp, err
+}
+
+type DownloadByChecksumOptions struct {
+	Algorithm      string   // One of sha1|sha256
+	Checksum       string   // Hex encoded checksum of the artifact
+	Repos          []string // Optional, limits the search to the given repositories
+	PreferredRepos []string // Optional, copies found in these repositories are tried first, in the given order
+}
+
+// DownloadByChecksum looks up the artifacts with the given checksum and copies the first one the caller is allowed to
+// read to the given target, after having verified its content against the checksum. Copies that can't be read or
+// whose content doesn't match are skipped. It returns the location of the copy downloaded.
+func (s *ArtifactService) DownloadByChecksum(ctx context.Context, opt *DownloadByChecksumOptions, file io.Writer) (*ItemLocation, *http.Response, error) {
+	if file == nil {
+		return nil, nil, fmt.Errorf("target is not allowed to be nil")
+	}
+	if opt == nil || opt.Checksum == "" {
+		return nil, nil, fmt.Errorf("checksum is required")
+	}
+
+	search := &ChecksumSearchOptions{Repos: opt.Repos}
+	switch strings.ToLower(opt.Algorithm) {
+	case ChecksumSha1:
+		search.Sha1 = opt.Checksum
+	case ChecksumSha256:
+		search.Sha256 = opt.Checksum
+	default:
+		return nil, nil, fmt.Errorf("unsupported checksum algorithm [%s]", opt.Algorithm)
+	}
+
+	results, resp, err := s.SearchByChecksum(ctx, search)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	type candidate struct{ repo, path string }
+	candidates := make([]candidate, 0, len(results.Results))
+	for _, r := range results.Results {
+		repo, path, err := r.Location()
+		if err != nil {
+			log.Warnf("[Artifactory Client] skipping checksum search result: %v", err)
+			continue
+		}
+		candidates = append(candidates, candidate{repo: repo, path: path})
+	}
+	rank := func(repo string) int {
+		for i, r := range opt.PreferredRepos {
+			if r == repo {
+				return i
+			}
+		}
+		return len(opt.PreferredRepos)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return rank(candidates[i].repo) < rank(candidates[j].repo)
+	})
+
+	// content is staged in a temporary file, so that a copy failing the verification never reaches the target
+	tmp, err := ioutil.TempFile("", "artifactory-download-")
+	if err != nil {
+		return nil, resp, errors.Wrap(err, "creating temporary file")
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	for _, c := range candidates {
+		if err := tmp.Truncate(0); err != nil {
+			return nil, resp, err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, resp, err
+		}
+
+		h, _ := newHash(opt.Algorithm)
+		resp, err = s.downloadFileContents(ctx, c.repo, c.path, io.MultiWriter(tmp, h))
+		if err != nil {
+			if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+				log.Debugf("[Artifactory Client] skipping [%s/%s]: %d", c.repo, c.path, resp.StatusCode)
+				continue
+			}
+			return nil, resp, err
+		}
+		if err := verifyChecksum(h, opt.Algorithm, c.repo+"/"+c.path, opt.Checksum); err != nil {
+			log.Warnf("[Artifactory Client] skipping [%s/%s]: %v", c.repo, c.path, err)
+			continue
+		}
+
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, resp, err
+		}
+		target := newChecksumWriter(file)
+		if _, err := io.Copy(target, tmp); err != nil {
+			return nil, resp, errors.Wrapf(err, "copying [%s/%s]", c.repo, c.path)
+		}
+		if recorder := s.buildInfoRecorder(); recorder != nil {
+			recorder.AddDependency(c.repo, c.path, target.checksums())
+		}
+		return &ItemLocation{Repo: String(c.repo), Path: String(c.path)}, resp, nil
+	}
+
+	return nil, resp, fmt.Errorf("no readable artifact found with %s [%s]", opt.Algorithm, opt.Checksum)
+}
@@ -0,0 +1,281 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	log "github.com/sirupsen/logrus"
+)
+
+// BuildService exposes the Build Info API endpoints from Artifactory
+type BuildService Service
+
+// BuildInfoTimeFormat is the format of the build start dates
+const BuildInfoTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+type BuildAgent struct {
+	Name    *string `json:"name,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+type BuildVcs struct {
+	Revision *string `json:"revision,omitempty"`
+	Url      *string `json:"url,omitempty"`
+	Branch   *string `json:"branch,omitempty"`
+	Message  *string `json:"message,omitempty"`
+}
+
+type BuildArtifact struct {
+	Type   *string `json:"type,omitempty"`
+	Name   *string `json:"name,omitempty"`
+	Path   *string `json:"path,omitempty"` // Path of the artifact in its repository
+	Sha1   *string `json:"sha1,omitempty"`
+	Sha256 *string `json:"sha256,omitempty"`
+	Md5    *string `json:"md5,omitempty"`
+	// Repository the artifact was deployed to, set by recent Artifactory versions
+	OriginalDeploymentRepo *string `json:"originalDeploymentRepo,omitempty"`
+}
+
+type BuildDependency struct {
+	Id          *string     `json:"id,omitempty"`
+	Type        *string     `json:"type,omitempty"`
+	Scopes      *[]string   `json:"scopes,omitempty"`
+	Sha1        *string     `json:"sha1,omitempty"`
+	Sha256      *string     `json:"sha256,omitempty"`
+	Md5         *string     `json:"md5,omitempty"`
+	RequestedBy *[][]string `json:"requestedBy,omitempty"` // Paths of dependency ids leading to this dependency
+}
+
+type BuildModule struct {
+	Id           *string            `json:"id,omitempty"`
+	Type         *string            `json:"type,omitempty"`
+	Repository   *string            `json:"repository,omitempty"`
+	Properties   *map[string]string `json:"properties,omitempty"`
+	Artifacts    *[]BuildArtifact   `json:"artifacts,omitempty"`
+	Dependencies *[]BuildDependency `json:"dependencies,omitempty"`
+}
+
+type BuildInfo struct {
+	Version              *string            `json:"version,omitempty"`
+	Name                 *string            `json:"name,omitempty"`   // Mandatory
+	Number               *string            `json:"number,omitempty"` // Mandatory
+	Type                 *string            `json:"type,omitempty"`
+	BuildAgent           *BuildAgent        `json:"buildAgent,omitempty"`
+	Agent                *BuildAgent        `json:"agent,omitempty"`
+	Started              *string            `json:"started,omitempty"` // Mandatory, in the BuildInfoTimeFormat
+	DurationMillis       *int64             `json:"durationMillis,omitempty"`
+	ArtifactoryPrincipal *string            `json:"artifactoryPrincipal,omitempty"`
+	Principal            *string            `json:"principal,omitempty"`
+	Url                  *string            `json:"url,omitempty"`
+	Vcs                  *[]BuildVcs        `json:"vcs,omitempty"`
+	Properties           *map[string]string `json:"properties,omitempty"` // Environment of the build, as buildInfo.env.* properties
+	Modules              *[]BuildModule     `json:"modules,omitempty"`
+}
+
+func (r BuildInfo) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// Adds the build info of a new build run.
+// Since: 2.2.0
+// Security: Requires a privileged user with deploy permissions
+func (s *BuildService) PublishBuildInfo(ctx context.Context, buildInfo *BuildInfo) (*http.Response, error) {
+	req, err := s.client.NewJSONEncodedRequest("PUT", "/api/build", buildInfo)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("[Artifactory Client] Build API [%s]", req.URL.String())
+	return s.client.Do(ctx, req, nil)
+}
+
+type BuildSummary struct {
+	Uri         *string `json:"uri,omitempty"` // Escaped name of the build, prefixed by a slash
+	LastStarted *string `json:"lastStarted,omitempty"`
+}
+
+// Name returns the unescaped name of the build
+func (r BuildSummary) Name() string {
+	name := strings.TrimPrefix(stringValue(r.Uri), "/")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		return unescaped
+	}
+	return name
+}
+
+type Builds struct {
+	Uri    *string         `json:"uri,omitempty"`
+	Builds *[]BuildSummary `json:"builds,omitempty"`
+}
+
+func (r Builds) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// Provides information on all builds.
+// Since: 2.2.0
+// Security: Requires a privileged user (can be anonymous)
+func (s *BuildService) ListBuilds(ctx context.Context) (*Builds, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/api/build", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypeJson)
+
+	builds := new(Builds)
+	resp, err := s.client.Do(ctx, req, builds)
+	return builds, resp, err
+}
+
+type BuildRunSummary struct {
+	Uri     *string `json:"uri,omitempty"` // Escaped number of the build run, prefixed by a slash
+	Started *string `json:"started,omitempty"`
+}
+
+// Number returns the unescaped number of the build run
+func (r BuildRunSummary) Number() string {
+	number := strings.TrimPrefix(stringValue(r.Uri), "/")
+	if unescaped, err := url.PathUnescape(number); err == nil {
+		return unescaped
+	}
+	return number
+}
+
+type BuildRuns struct {
+	Uri          *string            `json:"uri,omitempty"`
+	BuildNumbers *[]BuildRunSummary `json:"buildsNumbers,omitempty"`
+}
+
+func (r BuildRuns) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// Build Runs. Provides the build runs of the given build.
+// Since: 2.2.0
+// Security: Requires a privileged user (can be anonymous)
+func (s *BuildService) ListBuildRuns(ctx context.Context, buildName string) (*BuildRuns, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/api/build/%s", url.PathEscape(buildName)), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypeJson)
+
+	runs := new(BuildRuns)
+	resp, err := s.client.Do(ctx, req, runs)
+	return runs, resp, err
+}
+
+type buildInfoResponse struct {
+	Uri       *string    `json:"uri,omitempty"`
+	BuildInfo *BuildInfo `json:"buildInfo,omitempty"`
+}
+
+// Build Info. Provides the build info of the given build run.
+// Since: 2.2.0
+// Security: Requires a privileged user (can be anonymous)
+func (s *BuildService) GetBuildInfo(ctx context.Context, buildName string, buildNumber string) (*BuildInfo, *http.Response, error) {
+	path := fmt.Sprintf("/api/build/%s/%s", url.PathEscape(buildName), url.PathEscape(buildNumber))
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypeJson)
+
+	buildInfo := new(buildInfoResponse)
+	resp, err := s.client.Do(ctx, req, buildInfo)
+	if err != nil {
+		return nil, resp, err
+	}
+	if buildInfo.BuildInfo == nil {
+		return nil, resp, fmt.Errorf("no build info found for build [%s] number [%s]", buildName, buildNumber)
+	}
+	return buildInfo.BuildInfo, resp, nil
+}
+
+type DeleteBuildsOptions struct {
+	BuildNumbers []string `url:"buildNumbers,comma,omitempty"` // Build runs to delete, ignored when DeleteAll is set
+	// Also deletes the artifacts of the build runs. The dependencies are never deleted, as they are not owned by the build
+	Artifacts bool `url:"artifacts,int,omitempty"`
+	DeleteAll bool `url:"deleteAll,int,omitempty"` // Deletes all the runs of the build
+}
+
+// Removes the given build runs, or all the runs of the build, optionally with their artifacts.
+// Since: 2.3.0
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user with delete permissions
+func (s *BuildService) DeleteBuilds(ctx context.Context, buildName string, opt *DeleteBuildsOptions) (*http.Response, error) {
+	if opt == nil || (!opt.DeleteAll && len(opt.BuildNumbers) == 0) {
+		return nil, fmt.Errorf("no build run of [%s] to delete: build numbers or delete all are required", buildName)
+	}
+	path, err := client.AddOptions(fmt.Sprintf("/api/build/%s", url.PathEscape(buildName)), opt)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+// BuildDiffItem is an artifact, dependency or property compared between two build runs
+type BuildDiffItem struct {
+	Name   *string `json:"name,omitempty"` // Artifacts only
+	Id     *string `json:"id,omitempty"`   // Dependencies only
+	Type   *string `json:"type,omitempty"`
+	Sha1   *string `json:"sha1,omitempty"`
+	Sha256 *string `json:"sha256,omitempty"`
+	Md5    *string `json:"md5,omitempty"`
+	Key    *string `json:"key,omitempty"`   // Properties only
+	Value  *string `json:"value,omitempty"` // Properties only
+}
+
+type BuildDiffSection struct {
+	New       *[]BuildDiffItem `json:"new,omitempty"`
+	Updated   *[]BuildDiffItem `json:"updated,omitempty"`
+	Unchanged *[]BuildDiffItem `json:"unchanged,omitempty"`
+	Removed   *[]BuildDiffItem `json:"removed,omitempty"`
+}
+
+type BuildDiff struct {
+	Artifacts    *BuildDiffSection `json:"artifacts,omitempty"`
+	Dependencies *BuildDiffSection `json:"dependencies,omitempty"`
+	Properties   *BuildDiffSection `json:"properties,omitempty"`
+}
+
+func (r BuildDiff) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+type buildDiffOptions struct {
+	Diff string `url:"diff"`
+}
+
+// Builds Diff. Compares a build run with an older one and returns the new, updated, unchanged and removed
+// artifacts, dependencies and properties.
+// Since: 2.6.6
+// Security: Requires a privileged user (can be anonymous)
+func (s *BuildService) DiffBuilds(ctx context.Context, buildName string, buildNumber string, olderBuildNumber string) (*BuildDiff, *http.Response, error) {
+	path := fmt.Sprintf("/api/build/%s/%s", url.PathEscape(buildName), url.PathEscape(buildNumber))
+	path, err := client.AddOptions(path, buildDiffOptions{Diff: olderBuildNumber})
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypeJson)
+
+	diff := new(BuildDiff)
+	resp, err := s.client.Do(ctx, req, diff)
+	return diff, resp, err
+}
@@ -0,0 +1,279 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	PromotionItemArtifact   = "artifact"
+	PromotionItemDependency = "dependency"
+
+	PromotionOutcomePromoted = "promoted"
+	PromotionOutcomeDryRun   = "dry-run" // Would be promoted, nothing was changed
+	PromotionOutcomeFailed   = "failed"
+	PromotionOutcomeNotMoved = "not-moved" // Only the status of the build changed, no target repository was given
+	PromotionOutcomeUnknown  = "unknown"   // The outcome can't be inferred, the item may have been promoted
+)
+
+type BuildPromotion struct {
+	Status       *string              `json:"status,omitempty"` // New status of the build, like staged or released
+	Comment      *string              `json:"comment,omitempty"`
+	CiUser       *string              `json:"ciUser,omitempty"`
+	Timestamp    *string              `json:"timestamp,omitempty"` // In the BuildInfoTimeFormat. Default: now
+	DryRun       *bool                `json:"dryRun,omitempty"`
+	SourceRepo   *string              `json:"sourceRepo,omitempty"` // Promotes only the items found in this repository
+	TargetRepo   *string              `json:"targetRepo,omitempty"` // Optional, only the status is changed if omitted
+	Copy         *bool                `json:"copy,omitempty"`       // Copies the items instead of moving them. Default: false
+	Artifacts    *bool                `json:"artifacts,omitempty"`  // Promotes the build artifacts. Default: true
+	Dependencies *bool                `json:"dependencies,omitempty"`
+	Scopes       *[]string            `json:"scopes,omitempty"`     // Scopes of the dependencies to promote, all if omitted
+	Properties   *map[string][]string `json:"properties,omitempty"` // Properties set on the promoted items
+	FailFast     *bool                `json:"failFast,omitempty"`   // Stops at the first error. Default: true
+
+	// ReportItems fetches the build info before the promotion to infer the outcome of each of its items. It isn't
+	// sent to Artifactory. Default: false, no item is reported
+	ReportItems bool `json:"-"`
+}
+
+func (r BuildPromotion) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+type BuildPromotionMessage struct {
+	Level   *string `json:"level,omitempty"` // One of error|warning|info
+	Message *string `json:"message,omitempty"`
+}
+
+// PromotedItem is the outcome of the promotion of a single artifact or dependency of the build
+type PromotedItem struct {
+	Kind    string // One of artifact|dependency
+	Module  string
+	Name    string // Name of the artifact, or id of the dependency
+	Path    string // Path of the artifact in its repository, empty for the dependencies
+	Outcome string // One of promoted|dry-run|not-moved|failed|unknown
+	Message string // Error reported by Artifactory for the item, if any
+}
+
+type BuildPromotionResult struct {
+	Messages []BuildPromotionMessage
+	Items    []PromotedItem
+}
+
+// Failed returns the items whose promotion failed
+func (r BuildPromotionResult) Failed() []PromotedItem {
+	failed := make([]PromotedItem, 0)
+	for _, item := range r.Items {
+		if item.Outcome == PromotionOutcomeFailed {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+type buildPromotionResponse struct {
+	Messages []BuildPromotionMessage `json:"messages,omitempty"`
+}
+
+// Change the status of a build, optionally moving or copying the build's artifacts and its dependencies to a target
+// repository and setting properties on promoted artifacts. When the promotion fails, the result is returned along
+// with the error.
+//
+// Artifactory doesn't report the outcome of each item: when ReportItems is set, the outcomes are inferred from the
+// build info of the run and the messages returned. An artifact fails when an error message names its full path, in
+// its repository. The items excluded by the source repository are left out, and the outcome of the items which can't
+// be told apart, like the items of unknown repository or those no error is about when the promotion failed, is
+// unknown. Nothing is moved without a target repository.
+// Since: 2.3.3
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user with deploy permissions on the target repository
+func (s *BuildService) PromoteBuild(ctx context.Context, buildName string, buildNumber string, promotion *BuildPromotion) (*BuildPromotionResult, *http.Response, error) {
+	var buildInfo *BuildInfo
+	if promotion != nil && promotion.ReportItems {
+		var resp *http.Response
+		var err error
+		if buildInfo, resp, err = s.GetBuildInfo(ctx, buildName, buildNumber); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	path := fmt.Sprintf("/api/build/promote/%s/%s", url.PathEscape(buildName), url.PathEscape(buildNumber))
+	req, err := s.client.NewJSONEncodedRequest("POST", path, promotion)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	log.Debugf("[Artifactory Client] Build promotion API [%s]", req.URL.String())
+
+	promoted := new(buildPromotionResponse)
+	resp, err := s.client.Do(ctx, req, promoted)
+	if err != nil {
+		// a failed promotion still lists its messages in the body of the response
+		raw, ok := err.(*client.RawErrorResponse)
+		if !ok || json.Unmarshal(raw.Body, promoted) != nil || len(promoted.Messages) == 0 {
+			return nil, resp, err
+		}
+	}
+	return newBuildPromotionResult(buildInfo, promotion, promoted.Messages, err != nil), resp, err
+}
+
+func newBuildPromotionResult(buildInfo *BuildInfo, promotion *BuildPromotion, messages []BuildPromotionMessage, failed bool) *BuildPromotionResult {
+	result := &BuildPromotionResult{Messages: messages, Items: make([]PromotedItem, 0)}
+	if messages == nil {
+		result.Messages = make([]BuildPromotionMessage, 0)
+	}
+	if buildInfo == nil || buildInfo.Modules == nil {
+		return result
+	}
+
+	outcome := PromotionOutcomePromoted
+	switch {
+	case failed:
+		outcome = PromotionOutcomeUnknown
+	case stringValue(promotion.TargetRepo) == "":
+		outcome = PromotionOutcomeNotMoved
+	case promotion.DryRun != nil && *promotion.DryRun:
+		outcome = PromotionOutcomeDryRun
+	}
+	sourceRepo := stringValue(promotion.SourceRepo)
+	artifacts := promotion.Artifacts == nil || *promotion.Artifacts
+	dependencies := promotion.Dependencies != nil && *promotion.Dependencies
+	scopes := make(map[string]bool)
+	if promotion.Scopes != nil {
+		for _, scope := range *promotion.Scopes {
+			scopes[scope] = true
+		}
+	}
+
+	for _, module := range *buildInfo.Modules {
+		moduleId := stringValue(module.Id)
+		if artifacts && module.Artifacts != nil {
+			for _, a := range *module.Artifacts {
+				repo := stringValue(a.OriginalDeploymentRepo)
+				if sourceRepo != "" && repo != "" && repo != sourceRepo {
+					continue
+				}
+				item := PromotedItem{Kind: PromotionItemArtifact, Module: moduleId, Name: stringValue(a.Name), Path: stringValue(a.Path), Outcome: outcome}
+				if sourceRepo != "" && repo == "" {
+					// the artifact may not be in the source repository
+					item.Outcome = PromotionOutcomeUnknown
+					repo = sourceRepo
+				}
+				if msg := promotionError(messages, repo, item.Path); msg != "" {
+					item.Outcome = PromotionOutcomeFailed
+					item.Message = msg
+				}
+				result.Items = append(result.Items, item)
+			}
+		}
+		if dependencies && module.Dependencies != nil {
+			for _, d := range *module.Dependencies {
+				if len(scopes) > 0 && !inScopes(d.Scopes, scopes) {
+					continue
+				}
+				// the repository of the dependencies isn't known
+				item := PromotedItem{Kind: PromotionItemDependency, Module: moduleId, Name: stringValue(d.Id), Outcome: outcome}
+				if sourceRepo != "" {
+					item.Outcome = PromotionOutcomeUnknown
+				}
+				result.Items = append(result.Items, item)
+			}
+		}
+	}
+	return result
+}
+
+// promotionError returns the first error message naming the full path of the artifact, that is its path prefixed by
+// its repository, or by any repository when the repository is unknown
+func promotionError(messages []BuildPromotionMessage, repo string, filePath string) string {
+	filePath = strings.TrimPrefix(filePath, "/")
+	if filePath == "" {
+		return ""
+	}
+	for _, m := range messages {
+		if strings.EqualFold(stringValue(m.Level), "error") && namesPath(stringValue(m.Message), repo, filePath) {
+			return *m.Message
+		}
+	}
+	return ""
+}
+
+// namesPath tells whether the message names the file of the repository as a whole, in the repo/path or repo:path
+// forms: libs-release/org/foo.jar doesn't match org/foo.jar.sha1, org/libfoo.jar or libs-release/com/org/foo.jar
+func namesPath(message string, repo string, filePath string) bool {
+	for i := 0; i < len(message); {
+		j := strings.Index(message[i:], filePath)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(filePath)
+		if start > 0 && (end == len(message) || !continuesName(message[end:])) && namesRepo(message[:start-1], message[start-1], repo) {
+			return true
+		}
+		i = start + 1
+	}
+	return false
+}
+
+// namesRepo tells whether the message ends with the repository followed by the separator, any repository key when
+// the repository is unknown
+func namesRepo(message string, separator byte, repo string) bool {
+	if separator != '/' && separator != ':' {
+		return false
+	}
+	start := len(message)
+	for start > 0 && (isNameRune(message[start-1], false) || message[start-1] == '.') {
+		start--
+	}
+	key := message[start:]
+	if start > 0 && (message[start-1] == '/' || message[start-1] == ':') {
+		return false
+	}
+	if repo == "" {
+		return key != ""
+	}
+	return key == repo
+}
+
+// continuesName tells whether the rest of a message continues the path preceding it. A separator, like a dot, only
+// does if it's followed by another character of the path, so it can still end a sentence.
+func continuesName(rest string) bool {
+	if isNameRune(rest[0], false) || rest[0] == '/' {
+		return true
+	}
+	return isNameRune(rest[0], true) && len(rest) > 1 && isNameRune(rest[1], false)
+}
+
+// isNameRune tells whether the character may be part of a repository key or of a file name, the separators dot and
+// colon included if required
+func isNameRune(c byte, separators bool) bool {
+	switch c {
+	case '-', '_', '+', '~':
+		return true
+	case '.', ':':
+		return separators
+	}
+	return c >= utf8.RuneSelf || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+func inScopes(itemScopes *[]string, scopes map[string]bool) bool {
+	if itemScopes == nil {
+		return false
+	}
+	for _, scope := range *itemScopes {
+		if scopes[scope] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoteBuild(t *testing.T) {
+	buildInfo := &BuildInfo{
+		Name:   String("app"),
+		Number: String("7"),
+		Modules: &[]BuildModule{{
+			Id: String("org.example:app:1.0"),
+			Artifacts: &[]BuildArtifact{
+				{Name: String("app-1.0.jar"), Path: String("org/example/app/1.0/app-1.0.jar"), OriginalDeploymentRepo: String("libs-staging")},
+				{Name: String("app-1.0.pom"), Path: String("org/example/app/1.0/app-1.0.pom"), OriginalDeploymentRepo: String("libs-staging")},
+				{Name: String("app-1.0.zip"), Path: String("org/example/app/1.0/app-1.0.zip"), OriginalDeploymentRepo: String("libs-other")},
+				{Name: String("app-1.0.txt"), Path: String("org/example/app/1.0/app-1.0.txt")},
+			},
+			Dependencies: &[]BuildDependency{
+				{Id: String("org.example:lib:1.0"), Scopes: &[]string{"compile"}},
+				{Id: String("junit:junit:4.12"), Scopes: &[]string{"test"}},
+			},
+		}},
+	}
+	fail := false
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/build/app/7":
+			gets++
+			res, _ := json.Marshal(buildInfoResponse{BuildInfo: buildInfo})
+			_, _ = w.Write(res)
+		case "/api/build/promote/app/7":
+			assert.Equal(t, "POST", r.Method)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.NotContains(t, string(body), "ReportItems")
+			promotion := new(BuildPromotion)
+			assert.Nil(t, json.Unmarshal(body, promotion))
+			if fail {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = fmt.Fprint(w, `{"messages": [{"level": "error", "message": "Failed to copy libs-staging/org/example/app/1.0/app-1.0.pom: 100% of the quota used"}]}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"messages": []}`)
+		default:
+			t.Errorf("unexpected request [%s]", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	// the items aren't reported unless requested
+	result, _, err := v.Builds.PromoteBuild(ctx, "app", "7", &BuildPromotion{Status: String("released")})
+	assert.Nil(t, err)
+	assert.Empty(t, result.Items)
+	assert.Equal(t, 0, gets)
+
+	promotion := &BuildPromotion{
+		Status:       String("released"),
+		SourceRepo:   String("libs-staging"),
+		TargetRepo:   String("libs-release"),
+		Dependencies: Bool(true),
+		Scopes:       &[]string{"compile"},
+		DryRun:       Bool(true),
+		ReportItems:  true,
+	}
+	result, _, err = v.Builds.PromoteBuild(ctx, "app", "7", promotion)
+	assert.Nil(t, err)
+	assert.Equal(t, []PromotedItem{
+		{Kind: PromotionItemArtifact, Module: "org.example:app:1.0", Name: "app-1.0.jar", Path: "org/example/app/1.0/app-1.0.jar", Outcome: PromotionOutcomeDryRun},
+		{Kind: PromotionItemArtifact, Module: "org.example:app:1.0", Name: "app-1.0.pom", Path: "org/example/app/1.0/app-1.0.pom", Outcome: PromotionOutcomeDryRun},
+		{Kind: PromotionItemArtifact, Module: "org.example:app:1.0", Name: "app-1.0.txt", Path: "org/example/app/1.0/app-1.0.txt", Outcome: PromotionOutcomeUnknown},
+		{Kind: PromotionItemDependency, Module: "org.example:app:1.0", Name: "org.example:lib:1.0", Outcome: PromotionOutcomeUnknown},
+	}, result.Items)
+
+	// status only
+	result, _, err = v.Builds.PromoteBuild(ctx, "app", "7", &BuildPromotion{Status: String("released"), ReportItems: true})
+	assert.Nil(t, err)
+	if assert.Len(t, result.Items, 4) {
+		for _, item := range result.Items {
+			assert.Equal(t, PromotionOutcomeNotMoved, item.Outcome)
+		}
+	}
+
+	fail = true
+	promotion.DryRun = nil
+	result, _, err = v.Builds.PromoteBuild(ctx, "app", "7", promotion)
+	assert.NotNil(t, err)
+	if assert.NotNil(t, result) && assert.Len(t, result.Failed(), 1) {
+		assert.Equal(t, "app-1.0.pom", result.Failed()[0].Name)
+		assert.Equal(t, PromotionOutcomeUnknown, result.Items[0].Outcome)
+		assert.Equal(t, PromotionOutcomeUnknown, result.Items[3].Outcome)
+	}
+	assert.Len(t, result.Messages, 1)
+}
+
+func TestPromotionError(t *testing.T) {
+	messages := []BuildPromotionMessage{
+		{Level: String("info"), Message: String("Skipping libs-local/org/app-1.0.jar")},
+		{Level: String("error"), Message: String("Failed to copy libs-local/org/app-1.0.jar.sha1 (100% done)")},
+		{Level: String("error"), Message: String("Artifact 'libs-local:org/libapp-1.0.jar' already exists")},
+		{Level: String("error"), Message: String("Failed to copy libs-local/com/org/app-1.0.war")},
+		{Level: String("error"), Message: String("Failed to copy libs-local/org/app-1.0.pom.")},
+		{Level: String("error"), Message: String("Failed to move 'other-local:org/app-1.0.zip'")},
+	}
+	assert.Equal(t, "", promotionError(messages, "libs-local", "org/app-1.0.jar"))
+	assert.Equal(t, "Failed to copy libs-local/org/app-1.0.jar.sha1 (100% done)", promotionError(messages, "libs-local", "org/app-1.0.jar.sha1"))
+	assert.Equal(t, "Artifact 'libs-local:org/libapp-1.0.jar' already exists", promotionError(messages, "libs-local", "/org/libapp-1.0.jar"))
+	assert.Equal(t, "", promotionError(messages, "libs-local", "org/app-1.0.war"))
+	assert.Equal(t, "Failed to copy libs-local/org/app-1.0.pom.", promotionError(messages, "libs-local", "org/app-1.0.pom"))
+	assert.Equal(t, "", promotionError(messages, "libs-local", "org/app-1.0.zip"))
+	assert.Equal(t, "Failed to move 'other-local:org/app-1.0.zip'", promotionError(messages, "", "org/app-1.0.zip"))
+	assert.Equal(t, "", promotionError(messages, "", "app-1.0.zip"))
+	assert.Equal(t, "", promotionError(messages, "libs-local", ""))
+}
@@ -0,0 +1,254 @@
+package v1
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+)
+
+// buildInfoEnvPrefix is the prefix of the environment variables in the build info properties
+const buildInfoEnvPrefix = "buildInfo.env."
+
+// DefaultBuildEnvExcludes are the environment variables left out of the build info unless excludes are given, as
+// they are likely to hold credentials
+var DefaultBuildEnvExcludes = []string{"*password*", "*psw*", "*secret*", "*key*", "*token*", "*auth*"}
+
+// BuildInfoRecorder collects the artifacts uploaded and the dependencies downloaded through an ArtifactService, and
+// produces the build info of the build run ready to be published. It is safe for concurrent use.
+type BuildInfoRecorder struct {
+	mu      sync.Mutex
+	name    string
+	number  string
+	started time.Time
+	module  string
+	modules []*recordedModule
+	env     map[string]string
+}
+
+type recordedModule struct {
+	id           string
+	artifacts    []BuildArtifact
+	dependencies []BuildDependency
+	paths        map[string]int // Index of the items by kind and repository path, to record each item once
+}
+
+// NewBuildInfoRecorder returns a recorder for the given build run, started now. The items are recorded in a module
+// named after the build until SetModule is called.
+func NewBuildInfoRecorder(buildName string, buildNumber string) *BuildInfoRecorder {
+	return &BuildInfoRecorder{
+		name:    buildName,
+		number:  buildNumber,
+		started: time.Now(),
+		module:  buildName,
+		env:     make(map[string]string),
+	}
+}
+
+// SetModule sets the module of the items recorded from now on
+func (r *BuildInfoRecorder) SetModule(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.module = id
+}
+
+func (r *BuildInfoRecorder) currentModule() *recordedModule {
+	for _, m := range r.modules {
+		if m.id == r.module {
+			return m
+		}
+	}
+	m := &recordedModule{id: r.module, paths: make(map[string]int)}
+	r.modules = append(r.modules, m)
+	return m
+}
+
+// AddArtifact records an artifact of the current module. An artifact recorded again replaces the previous one.
+func (r *BuildInfoRecorder) AddArtifact(repoKey string, filePath string, checksums *Checksums) {
+	filePath = strings.TrimPrefix(filePath, "/")
+	name := path.Base(filePath)
+	artifact := BuildArtifact{Name: String(name), Path: String(filePath)}
+	if ext := strings.TrimPrefix(path.Ext(name), "."); ext != "" {
+		artifact.Type = String(ext)
+	}
+	if checksums != nil {
+		artifact.Md5, artifact.Sha1, artifact.Sha256 = checksums.Md5, checksums.Sha1, checksums.Sha256
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.currentModule()
+	key := "artifact:" + repoKey + "/" + filePath
+	if i, ok := m.paths[key]; ok {
+		m.artifacts[i] = artifact
+		return
+	}
+	m.paths[key] = len(m.artifacts)
+	m.artifacts = append(m.artifacts, artifact)
+}
+
+// AddDependency records a dependency of the current module. A dependency recorded again replaces the previous one.
+func (r *BuildInfoRecorder) AddDependency(repoKey string, filePath string, checksums *Checksums) {
+	filePath = strings.TrimPrefix(filePath, "/")
+	name := path.Base(filePath)
+	dependency := BuildDependency{Id: String(name)}
+	if ext := strings.TrimPrefix(path.Ext(name), "."); ext != "" {
+		dependency.Type = String(ext)
+	}
+	if checksums != nil {
+		dependency.Md5, dependency.Sha1, dependency.Sha256 = checksums.Md5, checksums.Sha1, checksums.Sha256
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.currentModule()
+	key := "dependency:" + repoKey + "/" + filePath
+	if i, ok := m.paths[key]; ok {
+		m.dependencies[i] = dependency
+		return
+	}
+	m.paths[key] = len(m.dependencies)
+	m.dependencies = append(m.dependencies, dependency)
+}
+
+type BuildEnvOptions struct {
+	Include []string // Patterns of the variable names to record. Default: all
+	Exclude []string // Patterns of the variable names to leave out. Default: DefaultBuildEnvExcludes
+}
+
+// AddEnv records the environment variables, given in the KEY=VALUE format of os.Environ, whose name matches an
+// include pattern and no exclude pattern. Patterns are matched case insensitively with path.Match.
+func (r *BuildInfoRecorder) AddEnv(env []string, opt *BuildEnvOptions) {
+	include := []string{"*"}
+	exclude := DefaultBuildEnvExcludes
+	if opt != nil && opt.Include != nil {
+		include = opt.Include
+	}
+	if opt != nil && opt.Exclude != nil {
+		exclude = opt.Exclude
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, variable := range env {
+		parts := strings.SplitN(variable, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		if matchesAny(parts[0], include) && !matchesAny(parts[0], exclude) {
+			r.env[parts[0]] = parts[1]
+		}
+	}
+}
+
+func matchesAny(name string, patterns []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildInfo returns the build info of the items recorded so far, lasting from the creation of the recorder to now
+func (r *BuildInfoRecorder) BuildInfo() *BuildInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	buildInfo := &BuildInfo{
+		Version:        String("1.0.1"),
+		Name:           String(r.name),
+		Number:         String(r.number),
+		Type:           String("GENERIC"),
+		Agent:          &BuildAgent{Name: String("go-artifactory")},
+		Started:        String(r.started.Format(BuildInfoTimeFormat)),
+		DurationMillis: new(int64),
+	}
+	*buildInfo.DurationMillis = int64(time.Since(r.started) / time.Millisecond)
+
+	if len(r.env) > 0 {
+		keys := make([]string, 0, len(r.env))
+		for k := range r.env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		properties := make(map[string]string, len(keys))
+		for _, k := range keys {
+			properties[buildInfoEnvPrefix+k] = r.env[k]
+		}
+		buildInfo.Properties = &properties
+	}
+
+	modules := make([]BuildModule, 0, len(r.modules))
+	for _, m := range r.modules {
+		module := BuildModule{Id: String(m.id)}
+		if len(m.artifacts) > 0 {
+			artifacts := append([]BuildArtifact(nil), m.artifacts...)
+			module.Artifacts = &artifacts
+		}
+		if len(m.dependencies) > 0 {
+			dependencies := append([]BuildDependency(nil), m.dependencies...)
+			module.Dependencies = &dependencies
+		}
+		modules = append(modules, module)
+	}
+	buildInfo.Modules = &modules
+	return buildInfo
+}
+
+// SetBuildInfoRecorder registers the recorder of the files uploaded and downloaded by the service, nil disables the
+// recording. Only the files transferred through UploadFileContents, DownloadFileContents, DownloadByChecksum and the
+// Maven methods are recorded, not the metadata and checksum files the service reads or writes on their behalf.
+func (s *ArtifactService) SetBuildInfoRecorder(recorder *BuildInfoRecorder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recorder = recorder
+}
+
+func (s *ArtifactService) buildInfoRecorder() *BuildInfoRecorder {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.recorder
+}
+
+// checksumWriter computes the checksums of the content written to the target
+type checksumWriter struct {
+	io.Writer
+	md5, sha1, sha256 hash.Hash
+}
+
+func newChecksumWriter(w io.Writer) *checksumWriter {
+	return &checksumWriter{Writer: w, md5: md5.New(), sha1: sha1.New(), sha256: sha256.New()}
+}
+
+func (w *checksumWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.md5.Write(p[:n])
+	w.sha1.Write(p[:n])
+	w.sha256.Write(p[:n])
+	return n, err
+}
+
+func (w *checksumWriter) SetContentLength(length int64) {
+	if r, ok := w.Writer.(client.ContentLengthReceiver); ok {
+		r.SetContentLength(length)
+	}
+}
+
+func (w *checksumWriter) checksums() *Checksums {
+	return &Checksums{
+		Md5:    String(hex.EncodeToString(w.md5.Sum(nil))),
+		Sha1:   String(hex.EncodeToString(w.sha1.Sum(nil))),
+		Sha256: String(hex.EncodeToString(w.sha256.Sum(nil))),
+	}
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildInfoRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			_, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			_, _ = fmt.Fprint(w, "dependency")
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	dir, _ := ioutil.TempDir("", "recorder")
+	defer os.RemoveAll(dir)
+	file := dir + "/app.jar"
+	assert.Nil(t, ioutil.WriteFile(file, []byte("artifact"), 0644))
+
+	recorder := NewBuildInfoRecorder("app", "42")
+	v.Artifacts.SetBuildInfoRecorder(recorder)
+	defer v.Artifacts.SetBuildInfoRecorder(nil)
+
+	_, err := v.Artifacts.DownloadFileContents(context.Background(), "libs-release", "org/lib/1.0/lib-1.0.jar", ioutil.Discard)
+	assert.Nil(t, err)
+
+	recorder.SetModule("app-core")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := v.Artifacts.UploadFileContents(context.Background(), "libs-local", fmt.Sprintf("app/%d/app.jar", i%2), "application/java-archive", file, nil)
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	recorder.AddEnv([]string{"JAVA_HOME=/usr/lib/jvm", "CI_TOKEN=secret", "HOME=/root", "BROKEN"}, nil)
+	recorder.AddEnv([]string{"GOPATH=/go"}, &BuildEnvOptions{Include: []string{"go*"}})
+
+	buildInfo := recorder.BuildInfo()
+	assert.Equal(t, "app", *buildInfo.Name)
+	assert.Equal(t, "42", *buildInfo.Number)
+	assert.Equal(t, map[string]string{
+		"buildInfo.env.JAVA_HOME": "/usr/lib/jvm",
+		"buildInfo.env.HOME":      "/root",
+		"buildInfo.env.GOPATH":    "/go",
+	}, *buildInfo.Properties)
+
+	modules := *buildInfo.Modules
+	if assert.Len(t, modules, 2) {
+		assert.Equal(t, "app", *modules[0].Id)
+		assert.Nil(t, modules[0].Artifacts)
+		if assert.Len(t, *modules[0].Dependencies, 1) {
+			dependency := (*modules[0].Dependencies)[0]
+			assert.Equal(t, "lib-1.0.jar", *dependency.Id)
+			assert.Equal(t, "jar", *dependency.Type)
+			assert.Equal(t, *computeChecksums([]byte("dependency")).Sha256, *dependency.Sha256)
+		}
+
+		assert.Equal(t, "app-core", *modules[1].Id)
+		if assert.Len(t, *modules[1].Artifacts, 2) {
+			artifact := (*modules[1].Artifacts)[0]
+			assert.Equal(t, "app.jar", *artifact.Name)
+			assert.Equal(t, *computeChecksums([]byte("artifact")).Sha1, *artifact.Sha1)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	log "github.com/sirupsen/logrus"
+)
+
+type BuildRetention struct {
+	DeleteBuildArtifacts         *bool     `json:"deleteBuildArtifacts,omitempty"`
+	Count                        *int      `json:"count,omitempty"`            // Number of most recent build runs to keep
+	MinimumBuildDate             *int64    `json:"minimumBuildDate,omitempty"` // Milliseconds since epoch, older build runs are discarded
+	BuildNumbersNotToBeDiscarded *[]string `json:"buildNumbersNotToBeDiscarded,omitempty"`
+}
+
+func (r BuildRetention) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+type BuildRetentionOptions struct {
+	Async bool `url:"async,omitempty"` // Discards the build runs in the background
+}
+
+// Control Build Retention. Discards the runs of a build beyond the most recent count or older than the minimum
+// build date, except the excluded build numbers, optionally with their artifacts.
+// Since: 5.2.1
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user with delete permissions
+func (s *BuildService) DiscardOldBuilds(ctx context.Context, buildName string, retention *BuildRetention, opt *BuildRetentionOptions) (*http.Response, error) {
+	path, err := client.AddOptions(fmt.Sprintf("/api/build/retention/%s", url.PathEscape(buildName)), opt)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewJSONEncodedRequest("POST", path, retention)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("[Artifactory Client] Build retention API [%s]", req.URL.String())
+	return s.client.Do(ctx, req, nil)
+}
+
+// BuildRun is a run of a build, as listed by ListBuildRuns
+type BuildRun struct {
+	Number  string
+	Started time.Time
+}
+
+// BuildDiscardPlan lists the build runs a retention policy keeps and discards, most recent first
+type BuildDiscardPlan struct {
+	BuildName string
+	Keep      []BuildRun
+	Discard   []BuildRun
+}
+
+// ParseBuildTime parses the start date of a build run
+func ParseBuildTime(value string) (time.Time, error) {
+	for _, layout := range []string{BuildInfoTimeFormat, time.RFC3339Nano} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid build time [%s]", value)
+}
+
+// PlanBuildRetention lists the runs of the build and returns the ones the retention policy would discard, without
+// discarding anything. It follows the rules of DiscardOldBuilds.
+func (s *BuildService) PlanBuildRetention(ctx context.Context, buildName string, retention *BuildRetention) (*BuildDiscardPlan, *http.Response, error) {
+	runs, resp, err := s.ListBuildRuns(ctx, buildName)
+	if err != nil {
+		return nil, resp, err
+	}
+	buildRuns := make([]BuildRun, 0)
+	if runs.BuildNumbers != nil {
+		for _, r := range *runs.BuildNumbers {
+			started, err := ParseBuildTime(stringValue(r.Started))
+			if err != nil {
+				return nil, resp, fmt.Errorf("build [%s] number [%s]: %v", buildName, r.Number(), err)
+			}
+			buildRuns = append(buildRuns, BuildRun{Number: r.Number(), Started: started})
+		}
+	}
+	plan := NewBuildDiscardPlan(buildRuns, retention)
+	plan.BuildName = buildName
+	return plan, resp, nil
+}
+
+// NewBuildDiscardPlan applies the retention policy to the build runs: the runs beyond the most recent count, or
+// started before the minimum build date, are discarded unless their number is excluded. Without count nor minimum
+// build date nothing is discarded.
+func NewBuildDiscardPlan(runs []BuildRun, retention *BuildRetention) *BuildDiscardPlan {
+	sorted := append([]BuildRun(nil), runs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Started.After(sorted[j].Started)
+	})
+
+	plan := &BuildDiscardPlan{Keep: make([]BuildRun, 0), Discard: make([]BuildRun, 0)}
+	excluded := make(map[string]bool)
+	if retention != nil && retention.BuildNumbersNotToBeDiscarded != nil {
+		for _, number := range *retention.BuildNumbersNotToBeDiscarded {
+			excluded[number] = true
+		}
+	}
+	for i, run := range sorted {
+		discard := false
+		if retention != nil && retention.Count != nil && i >= *retention.Count {
+			discard = true
+		}
+		if retention != nil && retention.MinimumBuildDate != nil && run.Started.Before(time.Unix(0, *retention.MinimumBuildDate*int64(time.Millisecond))) {
+			discard = true
+		}
+		if discard && !excluded[run.Number] {
+			plan.Discard = append(plan.Discard, run)
+		} else {
+			plan.Keep = append(plan.Keep, run)
+		}
+	}
+	return plan
+}
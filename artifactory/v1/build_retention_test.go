@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscardOldBuilds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/build/retention/app", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("async"))
+		body, _ := ioutil.ReadAll(r.Body)
+		retention := new(BuildRetention)
+		assert.Nil(t, json.Unmarshal(body, retention))
+		assert.Equal(t, 2, *retention.Count)
+		assert.Equal(t, []string{"1"}, *retention.BuildNumbersNotToBeDiscarded)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	count := 2
+	retention := &BuildRetention{Count: &count, BuildNumbersNotToBeDiscarded: &[]string{"1"}, DeleteBuildArtifacts: Bool(true)}
+	_, err := v.Builds.DiscardOldBuilds(context.Background(), "app", retention, &BuildRetentionOptions{Async: true})
+	assert.Nil(t, err)
+}
+
+func TestPlanBuildRetention(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/build/app", r.URL.Path)
+		_, _ = fmt.Fprint(w, `{"buildsNumbers": [
+			{"uri": "/1", "started": "2020-01-01T10:00:00.000+0000"},
+			{"uri": "/3", "started": "2020-03-01T10:00:00.000+0000"},
+			{"uri": "/2", "started": "2020-02-01T10:00:00.000+0000"},
+			{"uri": "/4", "started": "2020-04-01T10:00:00.000+0000"}
+		]}`)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	numbers := func(runs []BuildRun) []string {
+		res := make([]string, 0)
+		for _, r := range runs {
+			res = append(res, r.Number)
+		}
+		return res
+	}
+
+	count := 2
+	plan, _, err := v.Builds.PlanBuildRetention(context.Background(), "app", &BuildRetention{Count: &count, BuildNumbersNotToBeDiscarded: &[]string{"1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "app", plan.BuildName)
+	assert.Equal(t, []string{"4", "3", "1"}, numbers(plan.Keep))
+	assert.Equal(t, []string{"2"}, numbers(plan.Discard))
+
+	minimum := time.Date(2020, 2, 15, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+	plan, _, err = v.Builds.PlanBuildRetention(context.Background(), "app", &BuildRetention{MinimumBuildDate: &minimum})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"4", "3"}, numbers(plan.Keep))
+	assert.Equal(t, []string{"2", "1"}, numbers(plan.Discard))
+
+	plan, _, err = v.Builds.PlanBuildRetention(context.Background(), "app", nil)
+	assert.Nil(t, err)
+	assert.Len(t, plan.Discard, 0)
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilds(t *testing.T) {
+	var published *BuildInfo
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/build":
+			body, _ := ioutil.ReadAll(r.Body)
+			published = new(BuildInfo)
+			assert.Nil(t, json.Unmarshal(body, published))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/build":
+			_, _ = fmt.Fprint(w, `{"uri": "http://localhost/api/build", "builds": [{"uri": "/my%2Fbuild", "lastStarted": "2020-01-01T00:00:00.000+0000"}]}`)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/build/my%2Fbuild":
+			_, _ = fmt.Fprint(w, `{"uri": "http://localhost/api/build/my%2Fbuild", "buildsNumbers": [{"uri": "/1", "started": "2020-01-01T00:00:00.000+0000"}]}`)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/api/build/my%2Fbuild/1":
+			res, _ := json.Marshal(buildInfoResponse{BuildInfo: published})
+			_, _ = w.Write(res)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == "/api/build/my%2Fbuild":
+			assert.Equal(t, "1,2", r.URL.Query().Get("buildNumbers"))
+			assert.Equal(t, "1", r.URL.Query().Get("artifacts"))
+		default:
+			t.Errorf("unexpected request [%s %s]", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	buildInfo := &BuildInfo{
+		Name:    String("my/build"),
+		Number:  String("1"),
+		Started: String("2020-01-01T00:00:00.000+0000"),
+		Modules: &[]BuildModule{{
+			Id:           String("org.example:app:1.0"),
+			Artifacts:    &[]BuildArtifact{{Name: String("app-1.0.jar"), Sha1: String("da39a3ee5e6b4b0d3255bfef95601890afd80709")}},
+			Dependencies: &[]BuildDependency{{Id: String("junit:junit:4.12"), Scopes: &[]string{"test"}}},
+		}},
+		Properties: &map[string]string{"buildInfo.env.JAVA_HOME": "/usr/lib/jvm"},
+	}
+	_, err := v.Builds.PublishBuildInfo(ctx, buildInfo)
+	assert.Nil(t, err)
+
+	builds, _, err := v.Builds.ListBuilds(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, *builds.Builds, 1) {
+		assert.Equal(t, "my/build", (*builds.Builds)[0].Name())
+	}
+
+	runs, _, err := v.Builds.ListBuildRuns(ctx, "my/build")
+	assert.Nil(t, err)
+	if assert.Len(t, *runs.BuildNumbers, 1) {
+		assert.Equal(t, "1", (*runs.BuildNumbers)[0].Number())
+	}
+
+	fetched, _, err := v.Builds.GetBuildInfo(ctx, "my/build", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, buildInfo, fetched)
+
+	_, err = v.Builds.DeleteBuilds(ctx, "my/build", &DeleteBuildsOptions{BuildNumbers: []string{"1", "2"}, Artifacts: true})
+	assert.Nil(t, err)
+	_, err = v.Builds.DeleteBuilds(ctx, "my/build", nil)
+	assert.NotNil(t, err)
+}
+
+func TestDiffBuilds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/build/app/8", r.URL.Path)
+		assert.Equal(t, "7", r.URL.Query().Get("diff"))
+		_, _ = fmt.Fprint(w, `{
+			"artifacts": {"new": [{"name": "app-2.0.jar", "sha1": "b"}], "updated": [], "unchanged": [], "removed": [{"name": "app-1.0.jar", "sha1": "a"}]},
+			"dependencies": {"new": [], "updated": [{"id": "junit:junit:4.13"}], "unchanged": [], "removed": []},
+			"properties": {"new": [], "updated": [], "unchanged": [{"key": "buildInfo.env.JAVA_HOME", "value": "/usr/lib/jvm"}], "removed": []}
+		}`)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	diff, _, err := v.Builds.DiffBuilds(context.Background(), "app", "8", "7")
+	assert.Nil(t, err)
+	assert.Equal(t, "app-2.0.jar", *(*diff.Artifacts.New)[0].Name)
+	assert.Equal(t, "app-1.0.jar", *(*diff.Artifacts.Removed)[0].Name)
+	assert.Equal(t, "junit:junit:4.13", *(*diff.Dependencies.Updated)[0].Id)
+	assert.Equal(t, "/usr/lib/jvm", *(*diff.Properties.Unchanged)[0].Value)
+}
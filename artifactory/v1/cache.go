@@ -0,0 +1,324 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	cacheEntriesDir = "sha256"
+	cacheTmpDir     = "tmp"
+	cacheLockFile   = ".lock"
+)
+
+// DownloadCache is a content addressed cache of downloaded artifacts on the local file system. Entries are keyed by
+// their sha256 checksum and verified every time they are read. The cache directory can be shared by several processes:
+// changes to its content are serialized through a lock file.
+type DownloadCache struct {
+	dir     string
+	maxSize int64
+}
+
+// NewDownloadCache returns a cache stored in dir. When maxSize is greater than zero, the least recently used entries
+// are evicted as soon as the total size of the cache exceeds it.
+func NewDownloadCache(dir string, maxSize int64) (*DownloadCache, error) {
+	for _, d := range []string{dir, filepath.Join(dir, cacheEntriesDir), filepath.Join(dir, cacheTmpDir)} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			return nil, errors.Wrapf(err, "creating cache directory [%s]", d)
+		}
+	}
+	return &DownloadCache{dir: dir, maxSize: maxSize}, nil
+}
+
+func (c *DownloadCache) entryPath(sha256 string) string {
+	sha256 = strings.ToLower(sha256)
+	prefix := sha256
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return filepath.Join(c.dir, cacheEntriesDir, prefix, sha256)
+}
+
+func (c *DownloadCache) lock() (func() error, error) {
+	release, err := acquireFileLock(filepath.Join(c.dir, cacheLockFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "locking cache [%s]", c.dir)
+	}
+	return release, nil
+}
+
+// Contains reports whether an entry is stored for the checksum, without verifying it
+func (c *DownloadCache) Contains(sha256 string) bool {
+	_, err := os.Stat(c.entryPath(sha256))
+	return err == nil
+}
+
+// Get copies the entry stored for the checksum to the given target. It returns false if there is no entry, or if the
+// entry failed the verification, in which case it is removed from the cache.
+func (c *DownloadCache) Get(sha256 string, w io.Writer) (bool, error) {
+	path := c.entryPath(sha256)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	h, _ := newHash(ChecksumSha256)
+	if _, err := io.Copy(h, f); err != nil {
+		return false, errors.Wrapf(err, "reading cache entry [%s]", sha256)
+	}
+	if err := verifyChecksum(h, ChecksumSha256, path, sha256); err != nil {
+		log.Warnf("[Artifactory Client] removing corrupted cache entry: %v", err)
+		f.Close()
+		return false, c.Remove(sha256)
+	}
+
+	// entries are replaced by rename, so the open file is still the one verified
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return false, errors.Wrapf(err, "copying cache entry [%s]", sha256)
+	}
+
+	// the modification time tracks the last use of the entry for the LRU eviction
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil && !os.IsNotExist(err) {
+		log.Debugf("[Artifactory Client] touching cache entry [%s]: %v", sha256, err)
+	}
+	return true, nil
+}
+
+// Put stores the content read from r under its checksum. The content is rejected if it doesn't match the checksum.
+func (c *DownloadCache) Put(sha256 string, r io.Reader) error {
+	entry, err := c.newEntry(sha256)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(entry, r); err != nil {
+		entry.abort()
+		return errors.Wrapf(err, "writing cache entry [%s]", sha256)
+	}
+	return entry.commit()
+}
+
+// Remove deletes the entry stored for the checksum, if any
+func (c *DownloadCache) Remove(sha256 string) error {
+	release, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := os.Remove(c.entryPath(sha256)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Size returns the total size in bytes of the entries in the cache
+func (c *DownloadCache) Size() (int64, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, e := range entries {
+		size += e.Size()
+	}
+	return size, nil
+}
+
+// Evict removes the least recently used entries until the cache fits its maximum size
+func (c *DownloadCache) Evict() error {
+	release, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return c.evict("")
+}
+
+type cacheEntryInfo struct {
+	os.FileInfo
+	path string
+}
+
+func (c *DownloadCache) entries() ([]cacheEntryInfo, error) {
+	var entries []cacheEntryInfo
+	err := filepath.Walk(filepath.Join(c.dir, cacheEntriesDir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// entries can disappear while walking, if another process evicts them
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			entries = append(entries, cacheEntryInfo{FileInfo: info, path: path})
+		}
+		return nil
+	})
+	return entries, err
+}
+
+// evict must be called with the cache lock held. The entry at the keep path is never evicted.
+func (c *DownloadCache) evict(keep string) error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	for _, e := range entries {
+		size += e.Size()
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	for _, e := range entries {
+		if size <= c.maxSize {
+			break
+		}
+		if e.path == keep {
+			continue
+		}
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		log.Debugf("[Artifactory Client] evicted cache entry [%s]", e.Name())
+		size -= e.Size()
+	}
+	return nil
+}
+
+// cacheEntry is an entry being written to a temporary file, moved in place once verified
+type cacheEntry struct {
+	cache  *DownloadCache
+	sha256 string
+	file   *os.File
+	hash   hash.Hash
+}
+
+func (c *DownloadCache) newEntry(sha256 string) (*cacheEntry, error) {
+	f, err := ioutil.TempFile(filepath.Join(c.dir, cacheTmpDir), "entry-")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cache entry")
+	}
+	h, _ := newHash(ChecksumSha256)
+	return &cacheEntry{cache: c, sha256: strings.ToLower(sha256), file: f, hash: h}, nil
+}
+
+func (e *cacheEntry) Write(p []byte) (int, error) {
+	n, err := e.file.Write(p)
+	e.hash.Write(p[:n])
+	return n, err
+}
+
+func (e *cacheEntry) abort() {
+	e.file.Close()
+	os.Remove(e.file.Name())
+}
+
+func (e *cacheEntry) commit() error {
+	if err := e.file.Close(); err != nil {
+		os.Remove(e.file.Name())
+		return err
+	}
+	if err := verifyChecksum(e.hash, ChecksumSha256, e.sha256, e.sha256); err != nil {
+		os.Remove(e.file.Name())
+		return err
+	}
+
+	release, err := e.cache.lock()
+	if err != nil {
+		os.Remove(e.file.Name())
+		return err
+	}
+	defer release()
+
+	path := e.cache.entryPath(e.sha256)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		os.Remove(e.file.Name())
+		return err
+	}
+	if err := os.Rename(e.file.Name(), path); err != nil {
+		os.Remove(e.file.Name())
+		return errors.Wrapf(err, "storing cache entry [%s]", e.sha256)
+	}
+	return e.cache.evict(path)
+}
+
+// SetDownloadCache enables the local cache for the downloads made by the service, nil disables it. When enabled,
+// the checksum of an artifact is looked up before downloading it and the artifact is served from the cache if an
+// entry with the same checksum is found.
+func (s *ArtifactService) SetDownloadCache(cache *DownloadCache) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = cache
+}
+
+func (s *ArtifactService) downloadCache() *DownloadCache {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache
+}
+
+// cachedDownload serves the artifact from the cache, downloading it in the cache first if needed. It returns false
+// if the artifact can't be cached because its sha256 checksum is unknown, or can't be read from the storage API: some
+// paths, like the checksum files, have no storage info and the caller may be allowed to download but not to read it.
+func (s *ArtifactService) cachedDownload(ctx context.Context, cache *DownloadCache, repoKey string, filePath string, file io.Writer) (bool, *http.Response, error) {
+	info, resp, err := s.FileInfo(ctx, repoKey, filePath)
+	if err != nil {
+		log.Debugf("[Artifactory Client] Not caching [%s/%s], no storage info: %v", repoKey, filePath, err)
+		return false, resp, nil
+	}
+	if info.Checksums == nil || info.Checksums.Sha256 == nil || *info.Checksums.Sha256 == "" {
+		return false, resp, nil
+	}
+	sha256 := *info.Checksums.Sha256
+
+	if hit, err := cache.Get(sha256, file); err != nil {
+		return true, resp, err
+	} else if hit {
+		log.Debugf("[Artifactory Client] Serving [%s/%s] from cache", repoKey, filePath)
+		return true, resp, nil
+	}
+
+	entry, err := cache.newEntry(sha256)
+	if err != nil {
+		return true, resp, err
+	}
+	resp, err = s.download(ctx, repoKey, filePath, entry)
+	if err != nil {
+		entry.abort()
+		return true, resp, err
+	}
+	if err := entry.commit(); err != nil {
+		return true, resp, err
+	}
+
+	if hit, err := cache.Get(sha256, file); err != nil {
+		return true, resp, err
+	} else if !hit {
+		return true, resp, fmt.Errorf("cache entry of [%s/%s] evicted before being served", repoKey, filePath)
+	}
+	return true, resp, nil
+}
@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCache(t *testing.T, maxSize int64) (*DownloadCache, func()) {
+	dir, err := ioutil.TempDir("", "artifactory-cache-")
+	assert.Nil(t, err)
+	cache, err := NewDownloadCache(dir, maxSize)
+	assert.Nil(t, err)
+	return cache, func() { os.RemoveAll(dir) }
+}
+
+func TestDownloadCachePutGet(t *testing.T) {
+	cache, cleanup := newTestCache(t, 0)
+	defer cleanup()
+
+	content := []byte("cached content")
+	sha256 := *computeChecksums(content).Sha256
+
+	assert.NotNil(t, cache.Put(sha256, strings.NewReader("other content")))
+	assert.False(t, cache.Contains(sha256))
+
+	assert.Nil(t, cache.Put(sha256, bytes.NewReader(content)))
+	target := new(bytes.Buffer)
+	hit, err := cache.Get(sha256, target)
+	assert.Nil(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, content, target.Bytes())
+
+	// a corrupted entry is dropped on read
+	assert.Nil(t, ioutil.WriteFile(cache.entryPath(sha256), []byte("corrupted"), 0644))
+	target.Reset()
+	hit, err = cache.Get(sha256, target)
+	assert.Nil(t, err)
+	assert.False(t, hit)
+	assert.Equal(t, 0, target.Len())
+	assert.False(t, cache.Contains(sha256))
+}
+
+func TestDownloadCacheEviction(t *testing.T) {
+	cache, cleanup := newTestCache(t, 20)
+	defer cleanup()
+
+	sums := make([]string, 3)
+	for i := range sums {
+		content := []byte(fmt.Sprintf("content-%d", i)) // 9 bytes each
+		sums[i] = *computeChecksums(content).Sha256
+		assert.Nil(t, cache.Put(sums[i], bytes.NewReader(content)))
+		// entries must have distinct modification times to get a stable LRU order
+		past := time.Now().Add(time.Duration(i-10) * time.Minute)
+		assert.Nil(t, os.Chtimes(cache.entryPath(sums[i]), past, past))
+		if i == 1 {
+			// using the first entry makes the second one the least recently used
+			hit, err := cache.Get(sums[0], ioutil.Discard)
+			assert.Nil(t, err)
+			assert.True(t, hit)
+		}
+	}
+
+	assert.True(t, cache.Contains(sums[0]))
+	assert.False(t, cache.Contains(sums[1]))
+	assert.True(t, cache.Contains(sums[2]))
+	size, err := cache.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(18), size)
+}
+
+func TestDownloadFileContentsCached(t *testing.T) {
+	content := "dummy content"
+	sha256 := *computeChecksums([]byte(content)).Sha256
+	var downloads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/storage/generic-local/path/artifact":
+			_, _ = fmt.Fprintf(w, `{"repo": "generic-local", "path": "/path/artifact", "checksums": {"sha256": "%s"}}`, sha256)
+		case "/generic-local/path/artifact":
+			atomic.AddInt32(&downloads, 1)
+			_, _ = fmt.Fprint(w, content)
+		}
+	}))
+	defer server.Close()
+
+	cache, cleanup := newTestCache(t, 0)
+	defer cleanup()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	v.Artifacts.SetDownloadCache(cache)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			target := new(bytes.Buffer)
+			_, err := v.Artifacts.DownloadFileContents(context.Background(), "generic-local", "path/artifact", target)
+			assert.Nil(t, err)
+			assert.Equal(t, content, target.String())
+		}()
+	}
+	wg.Wait()
+	assert.True(t, atomic.LoadInt32(&downloads) >= 1)
+
+	before := atomic.LoadInt32(&downloads)
+	target := new(bytes.Buffer)
+	_, err := v.Artifacts.DownloadFileContents(context.Background(), "generic-local", "path/artifact", target)
+	assert.Nil(t, err)
+	assert.Equal(t, content, target.String())
+	assert.Equal(t, before, atomic.LoadInt32(&downloads))
+
+	entries, _ := filepath.Glob(filepath.Join(cache.dir, cacheTmpDir, "*"))
+	assert.Empty(t, entries)
+}
+
+func TestDownloadFileContentsWithoutStorageInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/libs-release/org/lib/1.0/lib-1.0.jar.sha1":
+			_, _ = fmt.Fprint(w, "checksum")
+		default:
+			// the storage API has no info about the checksum files
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cache, cleanup := newTestCache(t, 0)
+	defer cleanup()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	v.Artifacts.SetDownloadCache(cache)
+
+	target := new(bytes.Buffer)
+	_, err := v.Artifacts.DownloadFileContents(context.Background(), "libs-release", "org/lib/1.0/lib-1.0.jar.sha1", target)
+	assert.Nil(t, err)
+	assert.Equal(t, "checksum", target.String())
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+const (
+	ChecksumMd5    = "md5"
+	ChecksumSha1   = "sha1"
+	ChecksumSha256 = "sha256"
+)
+
+// ChecksumMismatchError is returned when downloaded content does not match the checksum it was expected to have
+type ChecksumMismatchError struct {
+	Path      string
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch for [%s]: expected %s, got %s", e.Algorithm, e.Path, e.Expected, e.Actual)
+}
+
+func newHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case ChecksumMd5:
+		return md5.New(), nil
+	case ChecksumSha1:
+		return sha1.New(), nil
+	case ChecksumSha256:
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported checksum algorithm [%s]", algorithm)
+}
+
+// verifyChecksum compares the digest accumulated in h with the expected hex encoded value
+func verifyChecksum(h hash.Hash, algorithm string, path string, expected string) error {
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return &ChecksumMismatchError{Path: path, Algorithm: algorithm, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// computeChecksums returns the md5, sha1 and sha256 checksums of the content
+func computeChecksums(content []byte) *Checksums {
+	md5sum := md5.Sum(content)
+	sha1sum := sha1.Sum(content)
+	sha256sum := sha256.Sum256(content)
+	return &Checksums{
+		Md5:    String(hex.EncodeToString(md5sum[:])),
+		Sha1:   String(hex.EncodeToString(sha1sum[:])),
+		Sha256: String(hex.EncodeToString(sha256sum[:])),
+	}
+}
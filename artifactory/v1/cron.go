@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CronExpression is a Quartz cron expression, as used by the replication and the other scheduled tasks of
+// Artifactory. The fields are kept as written, once validated.
+type CronExpression struct {
+	Seconds    string
+	Minutes    string
+	Hours      string
+	DayOfMonth string
+	Month      string
+	DayOfWeek  string
+	Year       string // Optional
+}
+
+func (c CronExpression) String() string {
+	fields := []string{c.Seconds, c.Minutes, c.Hours, c.DayOfMonth, c.Month, c.DayOfWeek}
+	if c.Year != "" {
+		fields = append(fields, c.Year)
+	}
+	return strings.Join(fields, " ")
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names of the values from min, if the field accepts them
+}
+
+var (
+	cronSeconds    = cronField{name: "seconds", min: 0, max: 59}
+	cronMinutes    = cronField{name: "minutes", min: 0, max: 59}
+	cronHours      = cronField{name: "hours", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day-of-month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12,
+		names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	cronDayOfWeek = cronField{name: "day-of-week", min: 1, max: 7,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+	cronYear = cronField{name: "year", min: 1970, max: 2099}
+)
+
+// ParseCronExpression validates a Quartz cron expression: 6 or 7 fields (seconds, minutes, hours, day-of-month,
+// month, day-of-week and the optional year) made of values, ranges, steps and lists, month and day names, and the
+// special characters '?', 'L', 'W' and '#'. As in Quartz, one of day-of-month and day-of-week must be '?'.
+func ParseCronExpression(expr string) (*CronExpression, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("invalid cron expression [%s]: expected 6 or 7 fields, found %d", expr, len(fields))
+	}
+	c := &CronExpression{
+		Seconds:    fields[0],
+		Minutes:    fields[1],
+		Hours:      fields[2],
+		DayOfMonth: fields[3],
+		Month:      fields[4],
+		DayOfWeek:  fields[5],
+	}
+	if len(fields) == 7 {
+		c.Year = fields[6]
+	}
+
+	checks := []struct {
+		field cronField
+		value string
+	}{
+		{cronSeconds, c.Seconds},
+		{cronMinutes, c.Minutes},
+		{cronHours, c.Hours},
+		{cronDayOfMonth, c.DayOfMonth},
+		{cronMonth, c.Month},
+		{cronDayOfWeek, c.DayOfWeek},
+	}
+	if c.Year != "" {
+		checks = append(checks, struct {
+			field cronField
+			value string
+		}{cronYear, c.Year})
+	}
+	for _, check := range checks {
+		if err := check.field.validate(check.value); err != nil {
+			return nil, fmt.Errorf("invalid cron expression [%s]: %s field [%s]: %v", expr, check.field.name, check.value, err)
+		}
+	}
+
+	switch {
+	case c.DayOfMonth == "?" && c.DayOfWeek == "?":
+		return nil, fmt.Errorf("invalid cron expression [%s]: '?' can't be used for both day-of-month and day-of-week", expr)
+	case c.DayOfMonth != "?" && c.DayOfWeek != "?":
+		return nil, fmt.Errorf("invalid cron expression [%s]: one of day-of-month and day-of-week must be '?'", expr)
+	}
+	return c, nil
+}
+
+func (f cronField) validate(value string) error {
+	if value == "?" {
+		if f.name != cronDayOfMonth.name && f.name != cronDayOfWeek.name {
+			return fmt.Errorf("'?' is only allowed for day-of-month and day-of-week")
+		}
+		return nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		if err := f.validateItem(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f cronField) validateItem(item string) error {
+	if item == "" {
+		return fmt.Errorf("empty list item")
+	}
+	if ok, err := f.validateSpecial(item); ok || err != nil {
+		return err
+	}
+
+	base := item
+	if i := strings.Index(item, "/"); i >= 0 {
+		base = item[:i]
+		step, err := strconv.Atoi(item[i+1:])
+		if err != nil || step <= 0 || step > f.max-f.min+1 {
+			return fmt.Errorf("invalid step [%s]", item[i+1:])
+		}
+	}
+	if base == "*" {
+		return nil
+	}
+
+	bounds := strings.SplitN(base, "-", 2)
+	from, err := f.value(bounds[0])
+	if err != nil {
+		return err
+	}
+	if len(bounds) == 2 {
+		to, err := f.value(bounds[1])
+		if err != nil {
+			return err
+		}
+		// Quartz allows ranges wrapping around, like FRI-MON, except for the years
+		if f.name == cronYear.name && to < from {
+			return fmt.Errorf("invalid range [%s]", base)
+		}
+	}
+	return nil
+}
+
+// validateSpecial checks the items using 'L', 'W' and '#', it returns false if the item uses none of them
+func (f cronField) validateSpecial(item string) (bool, error) {
+	switch f.name {
+	case cronDayOfMonth.name:
+		switch {
+		case item == "L" || item == "LW":
+			return true, nil
+		case strings.HasPrefix(item, "L-"):
+			offset, err := strconv.Atoi(item[2:])
+			if err != nil || offset < 1 || offset > 30 {
+				return true, fmt.Errorf("invalid offset from the last day [%s]", item)
+			}
+			return true, nil
+		case strings.HasSuffix(item, "W"):
+			_, err := f.value(strings.TrimSuffix(item, "W"))
+			return true, err
+		}
+	case cronDayOfWeek.name:
+		switch {
+		case item == "L":
+			return true, nil
+		case strings.HasSuffix(item, "L"):
+			_, err := f.value(strings.TrimSuffix(item, "L"))
+			return true, err
+		case strings.Contains(item, "#"):
+			parts := strings.SplitN(item, "#", 2)
+			if _, err := f.value(parts[0]); err != nil {
+				return true, err
+			}
+			nth, err := strconv.Atoi(parts[1])
+			if err != nil || nth < 1 || nth > 5 {
+				return true, fmt.Errorf("invalid occurrence in the month [%s]", item)
+			}
+			return true, nil
+		}
+	}
+	// anywhere else the special characters are rejected as invalid values
+	return false, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value [%s]", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value [%d] out of range [%d-%d]", v, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	valid := []string{
+		"0 0 12 * * ?",
+		"0 15 10 ? * *",
+		"0 15 10 * * ? 2025",
+		"0 0/5 14,18 * * ?",
+		"0 0-5 14 * * ?",
+		"0 10,44 14 ? 3 WED",
+		"0 15 10 ? * MON-FRI",
+		"0 15 10 L * ?",
+		"0 15 10 L-2 * ?",
+		"0 15 10 LW * ?",
+		"0 15 10 15W * ?",
+		"0 15 10 ? * 6L",
+		"0 15 10 ? * 6#3",
+		"0 15 10 ? jan-dec sun 2020-2030",
+		"*/30 * * 1/2 JUL ?",
+	}
+	for _, expr := range valid {
+		c, err := ParseCronExpression(expr)
+		if assert.Nil(t, err, expr) {
+			assert.Equal(t, expr, c.String())
+		}
+	}
+
+	invalid := []string{
+		"",
+		"0 0 12 * *",
+		"0 0 12 * * ? 2025 1",
+		"60 0 12 * * ?",
+		"0 0 24 * * ?",
+		"0 0 12 * * *",
+		"0 0 12 ? * ?",
+		"0 0 12 32 * ?",
+		"0 0 12 * 13 ?",
+		"0 0 12 ? * 8",
+		"0 0 12 ? FOO *",
+		"0 0/0 12 * * ?",
+		"0 0 12 L-31 * ?",
+		"0 0 12 ? * 6#6",
+		"0 0 L * * ?",
+		"0 0 12 * * ? 2030-2020",
+		"0 0,,1 12 * * ?",
+		"? 0 12 * * ?",
+	}
+	for _, expr := range invalid {
+		_, err := ParseCronExpression(expr)
+		assert.NotNil(t, err, expr)
+	}
+}
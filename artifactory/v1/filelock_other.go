@@ -0,0 +1,35 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package v1
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	fileLockRetryInterval = 10 * time.Millisecond
+	// fileLockStaleAfter is the age after which a lock left behind by a dead process is broken
+	fileLockStaleAfter = 10 * time.Minute
+)
+
+// acquireFileLock takes an exclusive lock by creating the file at path, blocking until it is available. It is the
+// fallback for the platforms without flock.
+func acquireFileLock(path string) (func() error, error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.Close()
+			return func() error { return os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > fileLockStaleAfter {
+			os.Remove(path)
+			continue
+		}
+		time.Sleep(fileLockRetryInterval)
+	}
+}
@@ -0,0 +1,281 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+)
+
+const (
+	mavenMetadataFile     = "maven-metadata.xml"
+	mavenSnapshotSuffix   = "-SNAPSHOT"
+	mavenDefaultExtension = "jar"
+)
+
+// MavenCoordinates identifies a Maven artifact by its groupId, artifactId and version (GAV) plus the optional
+// classifier and extension of the file
+type MavenCoordinates struct {
+	GroupId    string
+	ArtifactId string
+	Version    string
+	Classifier string
+	Extension  string // Default: jar
+}
+
+// ParseMavenCoordinates parses coordinates in the groupId:artifactId[:extension[:classifier]]:version format
+func ParseMavenCoordinates(gav string) (MavenCoordinates, error) {
+	parts := strings.Split(gav, ":")
+	for _, p := range parts {
+		if p == "" {
+			return MavenCoordinates{}, fmt.Errorf("invalid maven coordinates [%s]", gav)
+		}
+	}
+
+	switch len(parts) {
+	case 3:
+		return MavenCoordinates{GroupId: parts[0], ArtifactId: parts[1], Version: parts[2]}, nil
+	case 4:
+		return MavenCoordinates{GroupId: parts[0], ArtifactId: parts[1], Extension: parts[2], Version: parts[3]}, nil
+	case 5:
+		return MavenCoordinates{GroupId: parts[0], ArtifactId: parts[1], Extension: parts[2], Classifier: parts[3], Version: parts[4]}, nil
+	}
+	return MavenCoordinates{}, fmt.Errorf("invalid maven coordinates [%s]", gav)
+}
+
+func (c MavenCoordinates) String() string {
+	parts := []string{c.GroupId, c.ArtifactId, c.extension()}
+	if c.Classifier != "" {
+		parts = append(parts, c.Classifier)
+	}
+	return strings.Join(append(parts, c.Version), ":")
+}
+
+// IsSnapshot reports whether the coordinates reference a -SNAPSHOT version
+func (c MavenCoordinates) IsSnapshot() bool {
+	return strings.HasSuffix(c.Version, mavenSnapshotSuffix)
+}
+
+func (c MavenCoordinates) extension() string {
+	if c.Extension == "" {
+		return mavenDefaultExtension
+	}
+	return c.Extension
+}
+
+// ArtifactDir returns the repository folder holding all the versions of the artifact
+func (c MavenCoordinates) ArtifactDir() string {
+	return fmt.Sprintf("%s/%s", strings.Replace(c.GroupId, ".", "/", -1), c.ArtifactId)
+}
+
+// VersionDir returns the repository folder holding the files of this version
+func (c MavenCoordinates) VersionDir() string {
+	return fmt.Sprintf("%s/%s", c.ArtifactDir(), c.Version)
+}
+
+// FileName returns the layout compliant file name for the given file version. For releases the file version is the
+// version itself, for snapshots it can be either the -SNAPSHOT version or a timestamped one (1.0-20201019.101010-1)
+func (c MavenCoordinates) FileName(fileVersion string) string {
+	name := fmt.Sprintf("%s-%s", c.ArtifactId, fileVersion)
+	if c.Classifier != "" {
+		name = fmt.Sprintf("%s-%s", name, c.Classifier)
+	}
+	return fmt.Sprintf("%s.%s", name, c.extension())
+}
+
+// Path returns the layout compliant repository path of the artifact, without snapshot resolution
+func (c MavenCoordinates) Path() string {
+	return c.PathForVersion(c.Version)
+}
+
+// PathForVersion returns the layout compliant repository path of the artifact for the given file version
+func (c MavenCoordinates) PathForVersion(fileVersion string) string {
+	return fmt.Sprintf("%s/%s", c.VersionDir(), c.FileName(fileVersion))
+}
+
+// MavenMetadata is the model of a maven-metadata.xml file, at either the artifact or the version level
+type MavenMetadata struct {
+	XMLName      xml.Name         `xml:"metadata"`
+	ModelVersion string           `xml:"modelVersion,attr,omitempty"`
+	GroupId      string           `xml:"groupId,omitempty"`
+	ArtifactId   string           `xml:"artifactId,omitempty"`
+	Version      string           `xml:"version,omitempty"`
+	Versioning   *MavenVersioning `xml:"versioning,omitempty"`
+}
+
+type MavenVersioning struct {
+	Latest           string                 `xml:"latest,omitempty"`
+	Release          string                 `xml:"release,omitempty"`
+	Versions         []string               `xml:"versions>version,omitempty"`
+	Snapshot         *MavenSnapshot         `xml:"snapshot,omitempty"`
+	SnapshotVersions []MavenSnapshotVersion `xml:"snapshotVersions>snapshotVersion,omitempty"`
+	LastUpdated      string                 `xml:"lastUpdated,omitempty"`
+}
+
+type MavenSnapshot struct {
+	Timestamp   string `xml:"timestamp,omitempty"`
+	BuildNumber int    `xml:"buildNumber,omitempty"`
+	LocalCopy   bool   `xml:"localCopy,omitempty"`
+}
+
+type MavenSnapshotVersion struct {
+	Classifier string `xml:"classifier,omitempty"`
+	Extension  string `xml:"extension,omitempty"`
+	Value      string `xml:"value,omitempty"`
+	Updated    string `xml:"updated,omitempty"`
+}
+
+// ParseMavenMetadata decodes a maven-metadata.xml document
+func ParseMavenMetadata(r io.Reader) (*MavenMetadata, error) {
+	metadata := new(MavenMetadata)
+	if err := xml.NewDecoder(r).Decode(metadata); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", mavenMetadataFile, err)
+	}
+	return metadata, nil
+}
+
+// SnapshotFileVersion returns the timestamped file version for the given classifier and extension as declared in a
+// version level metadata. The snapshotVersions list is preferred, falling back to the snapshot timestamp and build number.
+func (m *MavenMetadata) SnapshotFileVersion(classifier string, extension string) (string, bool) {
+	if m.Versioning == nil {
+		return "", false
+	}
+	if extension == "" {
+		extension = mavenDefaultExtension
+	}
+	for _, sv := range m.Versioning.SnapshotVersions {
+		if sv.Classifier == classifier && sv.Extension == extension && sv.Value != "" {
+			return sv.Value, true
+		}
+	}
+
+	snapshot := m.Versioning.Snapshot
+	if snapshot == nil || snapshot.Timestamp == "" || snapshot.BuildNumber == 0 {
+		return "", false
+	}
+	base := strings.TrimSuffix(m.Version, mavenSnapshotSuffix)
+	return fmt.Sprintf("%s-%s-%d", base, snapshot.Timestamp, snapshot.BuildNumber), true
+}
+
+type MavenLatestVersionOptions struct {
+	GroupId    string   `url:"g"`
+	ArtifactId string   `url:"a"`
+	Version    string   `url:"v,omitempty"`          // Optional version prefix, e.g. 1.0-SNAPSHOT to get the latest integration version
+	Remote     bool     `url:"remote,int,omitempty"` // Also search remote repositories
+	Repos      []string `url:"repos,comma,omitempty"`
+}
+
+// Returns the latest Maven artifact version, based on layout rather than on artifact timestamps.
+// Since: 2.6.0
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) MavenLatestVersion(ctx context.Context, opt *MavenLatestVersionOptions) (*string, *http.Response, error) {
+	path, err := client.AddOptions("/api/search/latestVersion", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypePlain)
+
+	buf := new(bytes.Buffer)
+	resp, err := s.client.Do(ctx, req, buf)
+	if err != nil {
+		return nil, resp, err
+	}
+	return String(strings.TrimSpace(buf.String())), resp, nil
+}
+
+// GetMavenMetadata downloads and parses the maven-metadata.xml of the given coordinates. If the version of the
+// coordinates is empty the artifact level metadata is returned, otherwise the version level one.
+func (s *ArtifactService) GetMavenMetadata(ctx context.Context, repoKey string, coords MavenCoordinates) (*MavenMetadata, *http.Response, error) {
+	dir := coords.ArtifactDir()
+	if coords.Version != "" {
+		dir = coords.VersionDir()
+	}
+
+	buf := new(bytes.Buffer)
+	resp, err := s.downloadFileContents(ctx, repoKey, fmt.Sprintf("%s/%s", dir, mavenMetadataFile), buf)
+	if err != nil {
+		return nil, resp, err
+	}
+	metadata, err := ParseMavenMetadata(buf)
+	return metadata, resp, err
+}
+
+// ResolveMavenSnapshot returns the timestamped file version the -SNAPSHOT version of the coordinates resolves to.
+// Release versions are returned unchanged.
+func (s *ArtifactService) ResolveMavenSnapshot(ctx context.Context, repoKey string, coords MavenCoordinates) (*string, *http.Response, error) {
+	if !coords.IsSnapshot() {
+		return String(coords.Version), nil, nil
+	}
+
+	metadata, resp, err := s.GetMavenMetadata(ctx, repoKey, coords)
+	if err != nil {
+		return nil, resp, err
+	}
+	fileVersion, ok := metadata.SnapshotFileVersion(coords.Classifier, coords.extension())
+	if !ok {
+		// non unique snapshots are stored with the -SNAPSHOT version itself
+		fileVersion = coords.Version
+	}
+	log.Debugf("[Artifactory Client] Maven snapshot [%s] resolved to [%s]", coords, fileVersion)
+	return String(fileVersion), resp, nil
+}
+
+// ResolveMavenPath returns the repository path of the file the coordinates point to, resolving snapshots
+func (s *ArtifactService) ResolveMavenPath(ctx context.Context, repoKey string, coords MavenCoordinates) (*string, *http.Response, error) {
+	fileVersion, resp, err := s.ResolveMavenSnapshot(ctx, repoKey, coords)
+	if err != nil {
+		return nil, resp, err
+	}
+	return String(coords.PathForVersion(*fileVersion)), resp, nil
+}
+
+// DownloadMavenArtifact resolves the coordinates, copies the artifact to the given target and verifies it against
+// its .sha1 sidecar file. A *ChecksumMismatchError is returned if the verification fails, in which case the content
+// already written to the target must be discarded. When a build info recorder is set, the artifact is recorded as a
+// dependency once verified.
+func (s *ArtifactService) DownloadMavenArtifact(ctx context.Context, repoKey string, coords MavenCoordinates, file io.Writer) (*http.Response, error) {
+	if file == nil {
+		return nil, fmt.Errorf("target is not allowed to be nil")
+	}
+
+	filePath, resp, err := s.ResolveMavenPath(ctx, repoKey, coords)
+	if err != nil {
+		return resp, err
+	}
+
+	sidecar := new(bytes.Buffer)
+	resp, err = s.downloadFileContents(ctx, repoKey, *filePath+"."+ChecksumSha1, sidecar)
+	if err != nil {
+		return resp, fmt.Errorf("downloading checksum of [%s]: %v", *filePath, err)
+	}
+	// sidecar files may also contain the file name after the checksum
+	fields := strings.Fields(sidecar.String())
+	if len(fields) == 0 {
+		return resp, fmt.Errorf("empty checksum file for [%s]", *filePath)
+	}
+
+	h, _ := newHash(ChecksumSha1)
+	w := newChecksumWriter(io.MultiWriter(file, h))
+	resp, err = s.downloadFileContents(ctx, repoKey, *filePath, w)
+	if err != nil {
+		return resp, err
+	}
+	if err := verifyChecksum(h, ChecksumSha1, *filePath, fields[0]); err != nil {
+		return resp, err
+	}
+	if recorder := s.buildInfoRecorder(); recorder != nil {
+		recorder.AddDependency(repoKey, *filePath, w.checksums())
+	}
+	return resp, nil
+}
@@ -0,0 +1,378 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	mavenTimestampFormat   = "20060102.150405"
+	mavenLastUpdatedFormat = "20060102150405"
+	mavenPomExtension      = "pom"
+	mavenPomMediaType      = "application/x-maven-pom+xml"
+	mavenJarMediaType      = "application/java-archive"
+	mavenBinaryMediaType   = "application/octet-stream"
+)
+
+// MavenArtifactFile is a file deployed as part of a Maven publication. The content is either given in memory or read
+// from LocalFile, one of them is required.
+type MavenArtifactFile struct {
+	Classifier string
+	Extension  string // Default: jar
+	Content    []byte
+	LocalFile  string
+}
+
+// MavenDeployment describes a Maven publication: the main artifact, its POM and any attached artifacts
+type MavenDeployment struct {
+	Coordinates MavenCoordinates    // Coordinates of the main artifact, the extension is used as packaging
+	Artifact    *MavenArtifactFile  // Optional, a POM only publication is deployed when nil
+	Pom         []byte              // Optional, a minimal POM is generated when nil
+	Attachments []MavenArtifactFile // Sources, javadoc and any other classified artifact
+	Properties  []ArtifactoryProperty
+
+	// GenerateMetadata updates the artifact level maven-metadata.xml file. It is only required by repositories that
+	// don't calculate Maven metadata on their own, e.g. generic ones.
+	GenerateMetadata bool
+	// UniqueSnapshot deploys snapshots with a timestamp and build number rather than with the -SNAPSHOT version. The
+	// version level maven-metadata.xml file, which holds the last build number, is always updated for them.
+	UniqueSnapshot bool
+	// Timestamp of the deployment used for snapshots and metadata. Default: now
+	Timestamp time.Time
+}
+
+// MavenDeployedFile reports a file deployed by DeployMaven
+type MavenDeployedFile struct {
+	Path      string
+	Checksums Checksums
+}
+
+// MavenDeployResult is the outcome of DeployMaven
+type MavenDeployResult struct {
+	Coordinates MavenCoordinates
+	FileVersion string // The version used in file names, timestamped for unique snapshots
+	BuildNumber int    // The snapshot build number, 0 for releases and non unique snapshots
+	Files       []MavenDeployedFile
+}
+
+type mavenPom struct {
+	XMLName      xml.Name `xml:"project"`
+	Xmlns        string   `xml:"xmlns,attr"`
+	ModelVersion string   `xml:"modelVersion"`
+	GroupId      string   `xml:"groupId"`
+	ArtifactId   string   `xml:"artifactId"`
+	Version      string   `xml:"version"`
+	Packaging    string   `xml:"packaging,omitempty"`
+}
+
+// GenerateMavenPom returns a minimal POM for the given coordinates
+func GenerateMavenPom(coords MavenCoordinates) ([]byte, error) {
+	pom := mavenPom{
+		Xmlns:        "http://maven.apache.org/POM/4.0.0",
+		ModelVersion: "4.0.0",
+		GroupId:      coords.GroupId,
+		ArtifactId:   coords.ArtifactId,
+		Version:      coords.Version,
+	}
+	if packaging := coords.extension(); packaging != mavenDefaultExtension {
+		pom.Packaging = packaging
+	}
+	return marshalMavenXml(pom)
+}
+
+func marshalMavenXml(v interface{}) ([]byte, error) {
+	buf := bytes.NewBufferString(xml.Header)
+	enc := xml.NewEncoder(buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// DeployMaven publishes the POM, the main artifact and its attachments of a Maven publication. Each file is uploaded
+// with its checksums and, if requested, the maven-metadata.xml files are updated once all the files are deployed.
+func (s *ArtifactService) DeployMaven(ctx context.Context, repoKey string, deployment *MavenDeployment) (*MavenDeployResult, *http.Response, error) {
+	coords := deployment.Coordinates
+	if coords.GroupId == "" || coords.ArtifactId == "" || coords.Version == "" {
+		return nil, nil, fmt.Errorf("groupId, artifactId and version are required, got [%s]", coords)
+	}
+	// the coordinates of the publication itself don't carry a classifier
+	coords.Classifier = ""
+	if a := deployment.Artifact; a != nil && a.Content == nil && a.LocalFile == "" {
+		return nil, nil, fmt.Errorf("the artifact of [%s] has neither content nor local file", coords)
+	}
+	for _, a := range deployment.Attachments {
+		if a.Content == nil && a.LocalFile == "" {
+			extension := a.Extension
+			if extension == "" {
+				extension = mavenDefaultExtension
+			}
+			return nil, nil, fmt.Errorf("the attachment of [%s] with classifier [%s] and extension [%s] has neither content nor local file",
+				coords, a.Classifier, extension)
+		}
+	}
+
+	timestamp := deployment.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	timestamp = timestamp.UTC()
+
+	result := &MavenDeployResult{Coordinates: coords, FileVersion: coords.Version}
+
+	var versionMetadata *MavenMetadata
+	if coords.IsSnapshot() && deployment.UniqueSnapshot {
+		existing, resp, err := s.getMavenMetadataIfExists(ctx, repoKey, coords)
+		if err != nil {
+			return nil, resp, err
+		}
+		versionMetadata = existing
+		result.BuildNumber = 1
+		if versionMetadata.Versioning != nil && versionMetadata.Versioning.Snapshot != nil {
+			result.BuildNumber = versionMetadata.Versioning.Snapshot.BuildNumber + 1
+		}
+		result.FileVersion = fmt.Sprintf("%s-%s-%d", strings.TrimSuffix(coords.Version, mavenSnapshotSuffix),
+			timestamp.Format(mavenTimestampFormat), result.BuildNumber)
+	}
+
+	files := make([]MavenArtifactFile, 0, len(deployment.Attachments)+2)
+	if deployment.Artifact != nil {
+		main := *deployment.Artifact
+		main.Classifier = ""
+		main.Extension = coords.extension()
+		files = append(files, main)
+	}
+	pom := deployment.Pom
+	if pom == nil {
+		generated, err := GenerateMavenPom(coords)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "generating pom for [%s]", coords)
+		}
+		pom = generated
+	}
+	files = append(files, MavenArtifactFile{Extension: mavenPomExtension, Content: pom})
+	files = append(files, deployment.Attachments...)
+
+	var resp *http.Response
+	for _, f := range files {
+		content := f.Content
+		if content == nil && f.LocalFile != "" {
+			var err error
+			content, err = ioutil.ReadFile(f.LocalFile)
+			if err != nil {
+				return result, nil, errors.Wrapf(err, "reading file content [%s]", f.LocalFile)
+			}
+		}
+
+		fileCoords := coords
+		fileCoords.Classifier = f.Classifier
+		fileCoords.Extension = f.Extension
+		filePath := fileCoords.PathForVersion(result.FileVersion)
+
+		var err error
+		resp, err = s.uploadContents(ctx, repoKey, filePath, mavenMediaType(fileCoords.extension()), content, deployment.Properties)
+		if err != nil {
+			return result, resp, errors.Wrapf(err, "deploying [%s]", filePath)
+		}
+		checksums := computeChecksums(content)
+		result.Files = append(result.Files, MavenDeployedFile{Path: filePath, Checksums: *checksums})
+		if recorder := s.buildInfoRecorder(); recorder != nil {
+			recorder.AddArtifact(repoKey, filePath, checksums)
+		}
+
+		if versionMetadata != nil {
+			addSnapshotVersion(versionMetadata, f.Classifier, fileCoords.extension(), result.FileVersion, timestamp)
+		}
+	}
+	log.Debugf("[Artifactory Client] Maven deployed [%s] as [%s]", coords, result.FileVersion)
+
+	// the next unique snapshot takes its build number from the version level metadata
+	if versionMetadata != nil {
+		versionMetadata.ModelVersion = "1.1.0"
+		versionMetadata.GroupId = coords.GroupId
+		versionMetadata.ArtifactId = coords.ArtifactId
+		versionMetadata.Version = coords.Version
+		versionMetadata.Versioning.Snapshot = &MavenSnapshot{
+			Timestamp:   timestamp.Format(mavenTimestampFormat),
+			BuildNumber: result.BuildNumber,
+		}
+		versionMetadata.Versioning.LastUpdated = timestamp.Format(mavenLastUpdatedFormat)
+		metadataPath := fmt.Sprintf("%s/%s", coords.VersionDir(), mavenMetadataFile)
+		if resp, err := s.uploadMavenMetadata(ctx, repoKey, metadataPath, versionMetadata); err != nil {
+			return result, resp, err
+		}
+	}
+
+	if !deployment.GenerateMetadata {
+		return result, resp, nil
+	}
+
+	artifactCoords := coords
+	artifactCoords.Version = ""
+	artifactMetadata, resp, err := s.getMavenMetadataIfExists(ctx, repoKey, artifactCoords)
+	if err != nil {
+		return result, resp, err
+	}
+	addMavenVersion(artifactMetadata, coords, timestamp)
+	metadataPath := fmt.Sprintf("%s/%s", coords.ArtifactDir(), mavenMetadataFile)
+	resp, err = s.uploadMavenMetadata(ctx, repoKey, metadataPath, artifactMetadata)
+	return result, resp, err
+}
+
+// getMavenMetadataIfExists returns the metadata of the coordinates, or an empty one if it doesn't exist yet
+func (s *ArtifactService) getMavenMetadataIfExists(ctx context.Context, repoKey string, coords MavenCoordinates) (*MavenMetadata, *http.Response, error) {
+	metadata, resp, err := s.GetMavenMetadata(ctx, repoKey, coords)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return &MavenMetadata{Versioning: &MavenVersioning{}}, resp, nil
+	} else if err != nil {
+		return nil, resp, err
+	}
+	if metadata.Versioning == nil {
+		metadata.Versioning = &MavenVersioning{}
+	}
+	return metadata, resp, nil
+}
+
+func (s *ArtifactService) uploadMavenMetadata(ctx context.Context, repoKey string, metadataPath string, metadata *MavenMetadata) (*http.Response, error) {
+	content, err := marshalMavenXml(metadata)
+	if err != nil {
+		return nil, errors.Wrapf(err, "encoding [%s]", metadataPath)
+	}
+	resp, err := s.uploadContents(ctx, repoKey, metadataPath, "text/xml", content, nil)
+	if err != nil {
+		return resp, errors.Wrapf(err, "deploying [%s]", metadataPath)
+	}
+	return resp, nil
+}
+
+// addSnapshotVersion records the file in the snapshotVersions of a version level metadata, replacing the previous
+// build of the same classifier and extension
+func addSnapshotVersion(metadata *MavenMetadata, classifier string, extension string, fileVersion string, timestamp time.Time) {
+	entry := MavenSnapshotVersion{
+		Classifier: classifier,
+		Extension:  extension,
+		Value:      fileVersion,
+		Updated:    timestamp.Format(mavenLastUpdatedFormat),
+	}
+	for i, sv := range metadata.Versioning.SnapshotVersions {
+		if sv.Classifier == classifier && sv.Extension == extension {
+			metadata.Versioning.SnapshotVersions[i] = entry
+			return
+		}
+	}
+	metadata.Versioning.SnapshotVersions = append(metadata.Versioning.SnapshotVersions, entry)
+}
+
+// addMavenVersion records the version in an artifact level metadata
+func addMavenVersion(metadata *MavenMetadata, coords MavenCoordinates, timestamp time.Time) {
+	metadata.GroupId = coords.GroupId
+	metadata.ArtifactId = coords.ArtifactId
+
+	versioning := metadata.Versioning
+	found := false
+	for _, v := range versioning.Versions {
+		if v == coords.Version {
+			found = true
+			break
+		}
+	}
+	if !found {
+		versioning.Versions = append(versioning.Versions, coords.Version)
+		sort.SliceStable(versioning.Versions, func(i, j int) bool {
+			return compareMavenVersions(versioning.Versions[i], versioning.Versions[j]) < 0
+		})
+	}
+
+	versioning.Latest = versioning.Versions[len(versioning.Versions)-1]
+	if !coords.IsSnapshot() && (versioning.Release == "" || compareMavenVersions(versioning.Release, coords.Version) < 0) {
+		versioning.Release = coords.Version
+	}
+	versioning.LastUpdated = timestamp.Format(mavenLastUpdatedFormat)
+}
+
+// compareMavenVersions is a simplified Maven version ordering: numeric segments are compared as numbers, other ones
+// lexically, and a qualified version (1.0-SNAPSHOT, 1.0-rc1) sorts before its release (1.0)
+func compareMavenVersions(a string, b string) int {
+	as, bs := splitMavenVersion(a), splitMavenVersion(b)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareMavenVersionSegments(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(as) == len(bs):
+		return 0
+	case len(as) > len(bs):
+		return qualifierOrder(as[len(bs)])
+	default:
+		return -qualifierOrder(bs[len(as)])
+	}
+}
+
+// qualifierOrder tells whether a version with the extra segment sorts after (1) or before (-1) the one without it
+func qualifierOrder(extra string) int {
+	if _, ok := parseVersionNumber(extra); ok {
+		return 1
+	}
+	return -1
+}
+
+func compareMavenVersionSegments(a string, b string) int {
+	an, aok := parseVersionNumber(a)
+	bn, bok := parseVersionNumber(b)
+	switch {
+	case aok && bok:
+		if an < bn {
+			return -1
+		} else if an > bn {
+			return 1
+		}
+		return 0
+	case aok:
+		return 1
+	case bok:
+		return -1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func splitMavenVersion(v string) []string {
+	return strings.FieldsFunc(v, func(r rune) bool { return r == '.' || r == '-' })
+}
+
+func parseVersionNumber(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	n := 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n, true
+}
+
+func mavenMediaType(extension string) string {
+	switch extension {
+	case mavenPomExtension:
+		return mavenPomMediaType
+	case mavenDefaultExtension, "war", "ear":
+		return mavenJarMediaType
+	}
+	return mavenBinaryMediaType
+}
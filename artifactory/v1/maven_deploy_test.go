@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRepository is an in memory repository accepting deploys and serving them back
+type fakeRepository struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func newFakeRepository(t *testing.T) (*fakeRepository, *httptest.Server) {
+	repo := &fakeRepository{files: map[string][]byte{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		switch r.Method {
+		case "PUT":
+			body, _ := ioutil.ReadAll(r.Body)
+			checksums := computeChecksums(body)
+			assert.Equal(t, *checksums.Sha1, r.Header.Get("X-Checksum-Sha1"), r.URL.Path)
+			assert.Equal(t, *checksums.Md5, r.Header.Get("X-Checksum-Md5"), r.URL.Path)
+			assert.Equal(t, *checksums.Sha256, r.Header.Get("X-Checksum-Sha256"), r.URL.Path)
+			repo.files[r.URL.Path] = body
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			content, ok := repo.files[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(content)
+		}
+	}))
+	return repo, server
+}
+
+func (r *fakeRepository) paths() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	paths := make([]string, 0, len(r.files))
+	for p := range r.files {
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+func TestDeployMavenRelease(t *testing.T) {
+	repo, server := newFakeRepository(t)
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	deployment := &MavenDeployment{
+		Coordinates: MavenCoordinates{GroupId: "com.example", ArtifactId: "lib", Version: "1.0"},
+		Artifact:    &MavenArtifactFile{Content: []byte("jar")},
+		Attachments: []MavenArtifactFile{
+			{Classifier: "sources", Content: []byte("sources")},
+			{Classifier: "javadoc", LocalFile: "./fixtures/prova.txt"},
+		},
+		GenerateMetadata: true,
+	}
+	result, _, err := v.Artifacts.DeployMaven(context.Background(), "libs-release", deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0", result.FileVersion)
+	assert.Equal(t, 4, len(result.Files))
+	assert.ElementsMatch(t, []string{
+		"/libs-release/com/example/lib/1.0/lib-1.0.jar",
+		"/libs-release/com/example/lib/1.0/lib-1.0.pom",
+		"/libs-release/com/example/lib/1.0/lib-1.0-sources.jar",
+		"/libs-release/com/example/lib/1.0/lib-1.0-javadoc.jar",
+		"/libs-release/com/example/lib/maven-metadata.xml",
+	}, repo.paths())
+	assert.Contains(t, string(repo.files["/libs-release/com/example/lib/1.0/lib-1.0.pom"]), "<artifactId>lib</artifactId>")
+
+	deployment.Coordinates.Version = "1.1"
+	_, _, err = v.Artifacts.DeployMaven(context.Background(), "libs-release", deployment)
+	assert.Nil(t, err)
+
+	metadata, err := ParseMavenMetadata(bytes.NewReader(repo.files["/libs-release/com/example/lib/maven-metadata.xml"]))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.0", "1.1"}, metadata.Versioning.Versions)
+	assert.Equal(t, "1.1", metadata.Versioning.Release)
+	assert.Equal(t, "1.1", metadata.Versioning.Latest)
+}
+
+func TestDeployMavenWithoutContent(t *testing.T) {
+	repo, server := newFakeRepository(t)
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	deployment := &MavenDeployment{
+		Coordinates: MavenCoordinates{GroupId: "com.example", ArtifactId: "lib", Version: "1.0"},
+		Artifact:    &MavenArtifactFile{Content: []byte("jar")},
+		Attachments: []MavenArtifactFile{{Classifier: "sources"}},
+	}
+	_, _, err := v.Artifacts.DeployMaven(context.Background(), "libs-release", deployment)
+	assert.EqualError(t, err, "the attachment of [com.example:lib:jar:1.0] with classifier [sources] and extension [jar] has neither content nor local file")
+
+	deployment.Artifact = &MavenArtifactFile{}
+	_, _, err = v.Artifacts.DeployMaven(context.Background(), "libs-release", deployment)
+	assert.EqualError(t, err, "the artifact of [com.example:lib:jar:1.0] has neither content nor local file")
+	assert.Empty(t, repo.paths())
+}
+
+func TestDeployMavenUniqueSnapshot(t *testing.T) {
+	repo, server := newFakeRepository(t)
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	deployment := &MavenDeployment{
+		Coordinates:      MavenCoordinates{GroupId: "com.example", ArtifactId: "lib", Version: "2.0-SNAPSHOT", Extension: "zip"},
+		Artifact:         &MavenArtifactFile{Content: []byte("zip")},
+		GenerateMetadata: true,
+		UniqueSnapshot:   true,
+		Timestamp:        time.Date(2020, 10, 19, 10, 10, 10, 0, time.UTC),
+	}
+	result, _, err := v.Artifacts.DeployMaven(context.Background(), "libs-snapshot", deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.BuildNumber)
+	assert.Equal(t, "2.0-20201019.101010-1", result.FileVersion)
+
+	deployment.Timestamp = deployment.Timestamp.Add(time.Minute)
+	result, _, err = v.Artifacts.DeployMaven(context.Background(), "libs-snapshot", deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.BuildNumber)
+	assert.Equal(t, "2.0-20201019.101110-2", result.FileVersion)
+
+	pom := string(repo.files["/libs-snapshot/com/example/lib/2.0-SNAPSHOT/lib-2.0-20201019.101110-2.pom"])
+	assert.True(t, strings.Contains(pom, "<packaging>zip</packaging>"))
+
+	// the deployed snapshot must be resolvable by the maven helpers
+	path, _, err := v.Artifacts.ResolveMavenPath(context.Background(), "libs-snapshot", deployment.Coordinates)
+	assert.Nil(t, err)
+	assert.Equal(t, "com/example/lib/2.0-SNAPSHOT/lib-2.0-20201019.101110-2.zip", *path)
+}
+
+func TestDeployMavenUniqueSnapshotWithoutMetadata(t *testing.T) {
+	repo, server := newFakeRepository(t)
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	deployment := &MavenDeployment{
+		Coordinates:    MavenCoordinates{GroupId: "com.example", ArtifactId: "lib", Version: "2.0-SNAPSHOT"},
+		Artifact:       &MavenArtifactFile{Content: []byte("jar")},
+		UniqueSnapshot: true,
+		Timestamp:      time.Date(2020, 10, 19, 10, 10, 10, 0, time.UTC),
+	}
+	result, _, err := v.Artifacts.DeployMaven(context.Background(), "libs-snapshot", deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.BuildNumber)
+
+	deployment.Timestamp = deployment.Timestamp.Add(time.Minute)
+	result, _, err = v.Artifacts.DeployMaven(context.Background(), "libs-snapshot", deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.BuildNumber)
+	assert.Contains(t, repo.files, "/libs-snapshot/com/example/lib/2.0-SNAPSHOT/lib-2.0-20201019.101010-1.jar")
+
+	// only the version level metadata is maintained
+	assert.Contains(t, repo.files, "/libs-snapshot/com/example/lib/2.0-SNAPSHOT/maven-metadata.xml")
+	assert.NotContains(t, repo.files, "/libs-snapshot/com/example/lib/maven-metadata.xml")
+}
+
+func TestCompareMavenVersions(t *testing.T) {
+	assert.True(t, compareMavenVersions("1.2", "1.10") < 0)
+	assert.True(t, compareMavenVersions("1.0-SNAPSHOT", "1.0") < 0)
+	assert.True(t, compareMavenVersions("1.0", "1.0.1") < 0)
+	assert.Equal(t, 0, compareMavenVersions("1.0", "1.0"))
+}
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const snapshotMetadata = `<?xml version="1.0" encoding="UTF-8"?>
+<metadata modelVersion="1.1.0">
+  <groupId>com.example</groupId>
+  <artifactId>lib</artifactId>
+  <version>1.0-SNAPSHOT</version>
+  <versioning>
+    <snapshot>
+      <timestamp>20201019.101010</timestamp>
+      <buildNumber>3</buildNumber>
+    </snapshot>
+    <lastUpdated>20201019101010</lastUpdated>
+    <snapshotVersions>
+      <snapshotVersion>
+        <extension>jar</extension>
+        <value>1.0-20201019.101010-3</value>
+        <updated>20201019101010</updated>
+      </snapshotVersion>
+      <snapshotVersion>
+        <classifier>sources</classifier>
+        <extension>jar</extension>
+        <value>1.0-20201019.101010-2</value>
+        <updated>20201019101010</updated>
+      </snapshotVersion>
+    </snapshotVersions>
+  </versioning>
+</metadata>`
+
+func TestParseMavenCoordinates(t *testing.T) {
+	coords, err := ParseMavenCoordinates("com.example:lib:1.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "com/example/lib/1.0/lib-1.0.jar", coords.Path())
+
+	coords, err = ParseMavenCoordinates("com.example:lib:zip:dist:1.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "com/example/lib/1.0/lib-1.0-dist.zip", coords.Path())
+	assert.Equal(t, "com.example:lib:zip:dist:1.0", coords.String())
+
+	_, err = ParseMavenCoordinates("com.example:lib")
+	assert.NotNil(t, err)
+	_, err = ParseMavenCoordinates("com.example::1.0")
+	assert.NotNil(t, err)
+}
+
+func TestSnapshotFileVersion(t *testing.T) {
+	metadata, err := ParseMavenMetadata(strings.NewReader(snapshotMetadata))
+	assert.Nil(t, err)
+
+	v, ok := metadata.SnapshotFileVersion("", "jar")
+	assert.True(t, ok)
+	assert.Equal(t, "1.0-20201019.101010-3", v)
+
+	v, ok = metadata.SnapshotFileVersion("sources", "jar")
+	assert.True(t, ok)
+	assert.Equal(t, "1.0-20201019.101010-2", v)
+
+	// falls back to the snapshot element
+	v, ok = metadata.SnapshotFileVersion("javadoc", "jar")
+	assert.True(t, ok)
+	assert.Equal(t, "1.0-20201019.101010-3", v)
+}
+
+func TestMavenLatestVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/search/latestVersion", r.URL.Path)
+		assert.Equal(t, "com.example", r.URL.Query().Get("g"))
+		assert.Equal(t, "lib", r.URL.Query().Get("a"))
+		assert.Equal(t, "libs-release,libs-snapshot", r.URL.Query().Get("repos"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "1.2.3\n")
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	opt := &MavenLatestVersionOptions{GroupId: "com.example", ArtifactId: "lib", Repos: []string{"libs-release", "libs-snapshot"}}
+	version, _, err := v.Artifacts.MavenLatestVersion(context.Background(), opt)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.2.3", *version)
+}
+
+func TestDownloadMavenSnapshot(t *testing.T) {
+	const content = "dummy jar"
+	checksum := "d2b2ec3eb4b1ea1f3a8d1d1d4f7b6ba3cb6b2d73"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/libs-snapshot/com/example/lib/1.0-SNAPSHOT/maven-metadata.xml":
+			_, _ = fmt.Fprint(w, snapshotMetadata)
+		case "/libs-snapshot/com/example/lib/1.0-SNAPSHOT/lib-1.0-20201019.101010-3.jar":
+			_, _ = fmt.Fprint(w, content)
+		case "/libs-snapshot/com/example/lib/1.0-SNAPSHOT/lib-1.0-20201019.101010-3.jar.sha1":
+			_, _ = fmt.Fprintf(w, "%s  lib-1.0-20201019.101010-3.jar", checksum)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	coords := MavenCoordinates{GroupId: "com.example", ArtifactId: "lib", Version: "1.0-SNAPSHOT"}
+
+	path, _, err := v.Artifacts.ResolveMavenPath(context.Background(), "libs-snapshot", coords)
+	assert.Nil(t, err)
+	assert.Equal(t, "com/example/lib/1.0-SNAPSHOT/lib-1.0-20201019.101010-3.jar", *path)
+
+	recorder := NewBuildInfoRecorder("app", "1")
+	v.Artifacts.SetBuildInfoRecorder(recorder)
+	defer v.Artifacts.SetBuildInfoRecorder(nil)
+
+	// a wrong sidecar must be reported, and the artifact not recorded
+	target := new(bytes.Buffer)
+	_, err = v.Artifacts.DownloadMavenArtifact(context.Background(), "libs-snapshot", coords, target)
+	assert.IsType(t, &ChecksumMismatchError{}, err)
+	assert.Empty(t, *recorder.BuildInfo().Modules)
+
+	h, _ := newHash(ChecksumSha1)
+	_, _ = h.Write([]byte(content))
+	checksum = fmt.Sprintf("%x", h.Sum(nil))
+
+	target.Reset()
+	_, err = v.Artifacts.DownloadMavenArtifact(context.Background(), "libs-snapshot", coords, target)
+	assert.Nil(t, err)
+	assert.Equal(t, content, target.String())
+	if modules := recorder.BuildInfo().Modules; assert.NotNil(t, modules) && assert.Len(t, *(*modules)[0].Dependencies, 1) {
+		assert.Equal(t, checksum, *(*(*modules)[0].Dependencies)[0].Sha1)
+	}
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"io"
+	"time"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+)
+
+const (
+	TransferUpload   = "upload"
+	TransferDownload = "download"
+
+	// progressInterval is the minimum interval between two updates of the same transfer
+	progressInterval = 200 * time.Millisecond
+)
+
+// Progress is the state of a single transfer as reported to a ProgressObserver
+type Progress struct {
+	Direction   string // One of upload|download
+	RepoKey     string
+	Path        string
+	Transferred int64         // Bytes transferred so far
+	Total       int64         // Total bytes of the transfer, -1 when unknown
+	Rate        float64       // Average rate in bytes per second
+	ETA         time.Duration // Estimated time to completion, -1 when unknown
+	Done        bool          // Set on the last update of the transfer, whatever its outcome
+}
+
+// ProgressObserver receives the progress of uploads and downloads. Updates of different transfers can be delivered
+// concurrently, so implementations must be safe for concurrent use. Updates of the same transfer are sequential.
+type ProgressObserver interface {
+	OnProgress(p Progress)
+}
+
+// ProgressFunc adapts a function to the ProgressObserver interface
+type ProgressFunc func(p Progress)
+
+func (f ProgressFunc) OnProgress(p Progress) { f(p) }
+
+// SetProgressObserver registers the observer notified of the progress of every transfer made by the service, nil
+// disables the notifications.
+func (s *ArtifactService) SetProgressObserver(observer ProgressObserver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress = observer
+}
+
+func (s *ArtifactService) progressObserver() ProgressObserver {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.progress
+}
+
+// newProgressTracker returns a tracker for a new transfer, or nil if no observer is registered
+func (s *ArtifactService) newProgressTracker(direction string, repoKey string, path string, total int64) *progressTracker {
+	observer := s.progressObserver()
+	if observer == nil {
+		return nil
+	}
+	return &progressTracker{
+		observer: observer,
+		start:    time.Now(),
+		progress: Progress{Direction: direction, RepoKey: repoKey, Path: path, Total: total, ETA: -1},
+	}
+}
+
+// progressTracker accumulates the bytes of a single transfer and notifies the observer. A nil tracker is a no-op.
+type progressTracker struct {
+	observer ProgressObserver
+	start    time.Time
+	last     time.Time
+	progress Progress
+}
+
+func (t *progressTracker) add(n int) {
+	if t == nil || n <= 0 {
+		return
+	}
+	t.progress.Transferred += int64(n)
+	if now := time.Now(); now.Sub(t.last) >= progressInterval {
+		t.last = now
+		t.notify(now)
+	}
+}
+
+func (t *progressTracker) setTotal(total int64) {
+	if t == nil {
+		return
+	}
+	t.progress.Total = total
+}
+
+func (t *progressTracker) done() {
+	if t == nil {
+		return
+	}
+	t.progress.Done = true
+	t.notify(time.Now())
+}
+
+func (t *progressTracker) notify(now time.Time) {
+	p := t.progress
+	if elapsed := now.Sub(t.start).Seconds(); elapsed > 0 {
+		p.Rate = float64(p.Transferred) / elapsed
+	}
+	switch {
+	case p.Done:
+		p.ETA = 0
+	case p.Total >= 0 && p.Rate > 0:
+		p.ETA = time.Duration(float64(p.Total-p.Transferred) / p.Rate * float64(time.Second))
+	default:
+		p.ETA = -1
+	}
+	t.observer.OnProgress(p)
+}
+
+// progressReader tracks the bytes read from an upload body
+type progressReader struct {
+	io.Reader
+	tracker *progressTracker
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.tracker.add(n)
+	return n, err
+}
+
+// progressWriter tracks the bytes of a download written to the target
+type progressWriter struct {
+	io.Writer
+	tracker *progressTracker
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.tracker.add(n)
+	return n, err
+}
+
+func (w *progressWriter) SetContentLength(length int64) {
+	w.tracker.setTotal(length)
+	if r, ok := w.Writer.(client.ContentLengthReceiver); ok {
+		r.SetContentLength(length)
+	}
+}
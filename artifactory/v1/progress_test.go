@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	mu      sync.Mutex
+	updates map[string][]Progress
+}
+
+func (o *recordingObserver) OnProgress(p Progress) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	key := p.Direction + ":" + p.Path
+	o.updates[key] = append(o.updates[key], p)
+}
+
+func TestProgressObserver(t *testing.T) {
+	content := strings.Repeat("x", 64*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			_, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+			_, _ = fmt.Fprint(w, content)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	observer := &recordingObserver{updates: map[string][]Progress{}}
+	v.Artifacts.SetProgressObserver(observer)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		path := fmt.Sprintf("path/file-%d", i)
+		go func() {
+			defer wg.Done()
+			_, err := v.Artifacts.uploadContents(context.Background(), "generic-local", path, "text/plain", []byte(content), nil)
+			assert.Nil(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := v.Artifacts.DownloadFileContents(context.Background(), "generic-local", path, new(bytes.Buffer))
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 8, len(observer.updates))
+	for key, updates := range observer.updates {
+		last := updates[len(updates)-1]
+		assert.True(t, last.Done, key)
+		assert.Equal(t, int64(len(content)), last.Transferred, key)
+		assert.Equal(t, int64(len(content)), last.Total, key)
+		assert.Equal(t, "generic-local", last.RepoKey, key)
+	}
+
+	v.Artifacts.SetProgressObserver(nil)
+	_, err := v.Artifacts.DownloadFileContents(context.Background(), "generic-local", "path/untracked", new(bytes.Buffer))
+	assert.Nil(t, err)
+	_, tracked := observer.updates["download:path/untracked"]
+	assert.False(t, tracked)
+}
@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+)
+
+// ReindexOptions are the parameters of the metadata calculation requests, each endpoint ignores the ones it doesn't support
+type ReindexOptions struct {
+	Async      bool   // Schedules the calculation instead of waiting for it, where supported
+	Path       string // YUM only: path of the metadata root in the repository
+	WriteProps bool   // Debian only: also writes the package properties on the artifacts
+	Force      bool   // Maven index only: forces the index calculation even if it isn't due yet
+}
+
+// ReindexResult is the outcome of a metadata calculation request
+type ReindexResult struct {
+	RepoKey     *string `json:"repoKey,omitempty"`
+	PackageType *string `json:"packageType,omitempty"`
+	Async       *bool   `json:"async,omitempty"`
+	Message     *string `json:"message,omitempty"` // Message returned by Artifactory, if any
+}
+
+func (r ReindexResult) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// reindexPackageTypes are the package types served by the generic /api/{packageType}/{repoKey}/reindex endpoint
+var reindexPackageTypes = map[string]bool{
+	"npm": true, "helm": true, "nuget": true, "pypi": true, "cocoapods": true, "conda": true, "go": true,
+	"gems": true, "alpine": true, "cran": true, "composer": true, "chef": true, "puppet": true, "opkg": true,
+}
+
+type asyncQuery struct {
+	Async bool `url:"async,int,omitempty"`
+}
+
+type yumQuery struct {
+	Path  string `url:"path,omitempty"`
+	Async bool   `url:"async,int,omitempty"`
+}
+
+type debianQuery struct {
+	Async      bool `url:"async,int,omitempty"`
+	WriteProps bool `url:"writeProps,int,omitempty"`
+}
+
+type mavenIndexQuery struct {
+	Repos []string `url:"repos,comma,omitempty"`
+	Force bool     `url:"force,int,omitempty"`
+}
+
+func (s *RepositoriesService) reindex(ctx context.Context, packageType string, repoKey string, path string, query interface{}, async bool) (*ReindexResult, *http.Response, error) {
+	path, err := client.AddOptions(path, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypePlain)
+
+	buf := new(bytes.Buffer)
+	resp, err := s.client.Do(ctx, req, buf)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	result := &ReindexResult{RepoKey: String(repoKey), PackageType: String(packageType), Async: &async}
+	if msg := strings.TrimSpace(buf.String()); msg != "" {
+		result.Message = String(msg)
+	}
+	return result, resp, nil
+}
+
+func reindexOptions(opt *ReindexOptions) ReindexOptions {
+	if opt == nil {
+		return ReindexOptions{}
+	}
+	return *opt
+}
+
+// Calculates/recalculates the YUM metadata for a repository, optionally limited to the given path.
+// Since: 2.3.5
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) CalculateYumMetadata(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	o := reindexOptions(opt)
+	return s.reindex(ctx, "rpm", repoKey, fmt.Sprintf("/api/yum/%s", repoKey), yumQuery{Path: o.Path, Async: o.Async}, o.Async)
+}
+
+// Calculates/recalculates the Packages and Release metadata of a Debian repository.
+// Since: 3.3.0
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexDebian(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	o := reindexOptions(opt)
+	return s.reindex(ctx, "debian", repoKey, fmt.Sprintf("/api/deb/reindex/%s", repoKey), debianQuery{Async: o.Async, WriteProps: o.WriteProps}, o.Async)
+}
+
+// Calculates the Maven index of the given repositories. An empty list calculates the index of all the repositories.
+// Since: 2.5.0
+// Security: Requires a valid admin user
+func (s *RepositoriesService) CalculateMavenIndex(ctx context.Context, repoKeys []string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	o := reindexOptions(opt)
+	repoKey := strings.Join(repoKeys, ",")
+	// the index is always calculated asynchronously
+	return s.reindex(ctx, "maven", repoKey, "/api/maven", mavenIndexQuery{Repos: repoKeys, Force: o.Force}, true)
+}
+
+// Recalculates the npm search index for an npm repository.
+// Since: 3.2.0
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexNpm(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	return s.ReindexPackages(ctx, "npm", repoKey, opt)
+}
+
+// Calculates Helm chart index on the specified path (local repositories only).
+// Since: 5.8
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexHelm(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	return s.ReindexPackages(ctx, "helm", repoKey, opt)
+}
+
+// Recalculates all the NuGet packages for a repository (local/cache/virtual), and re-annotate the NuGet properties
+// for each NuGet package according to it's internal nuspec file.
+// Since: 3.0.3
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexNuGet(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	return s.ReindexPackages(ctx, "nuget", repoKey, opt)
+}
+
+// Recalculates the index for a PyPI repository.
+// Since: 3.0.3
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexPyPi(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	return s.ReindexPackages(ctx, "pypi", repoKey, opt)
+}
+
+// Recalculates the index for a CocoaPods repository.
+// Since: 3.3.0
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexCocoaPods(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	return s.ReindexPackages(ctx, "cocoapods", repoKey, opt)
+}
+
+// Recalculates the channel index for a Conda repository.
+// Since: 6.18.0
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexConda(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	return s.ReindexPackages(ctx, "conda", repoKey, opt)
+}
+
+// Recalculates the module index for a Go repository.
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexGo(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	return s.ReindexPackages(ctx, "go", repoKey, opt)
+}
+
+// ReindexPackages triggers the generic /api/{packageType}/{repoKey}/reindex endpoint shared by most package types
+// (npm, helm, nuget, pypi, cocoapods, conda, go, gems, alpine...). The package type isn't checked, see Reindex.
+// Notes: Requires Artifactory Pro
+// Security: Requires a valid admin user
+func (s *RepositoriesService) ReindexPackages(ctx context.Context, packageType string, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	o := reindexOptions(opt)
+	packageType = strings.ToLower(packageType)
+	return s.reindex(ctx, packageType, repoKey, fmt.Sprintf("/api/%s/%s/reindex", packageType, repoKey), asyncQuery{Async: o.Async}, o.Async)
+}
+
+// Reindex triggers the metadata calculation endpoint matching the package type of a repository. It fails for the
+// package types without metadata calculation, like docker or generic.
+func (s *RepositoriesService) Reindex(ctx context.Context, packageType string, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	switch strings.ToLower(packageType) {
+	case "rpm", "yum":
+		return s.CalculateYumMetadata(ctx, repoKey, opt)
+	case "debian", "deb":
+		return s.ReindexDebian(ctx, repoKey, opt)
+	case "maven", "gradle", "ivy", "sbt":
+		return s.CalculateMavenIndex(ctx, []string{repoKey}, opt)
+	case "":
+		return nil, nil, fmt.Errorf("no package type given for repository [%s]", repoKey)
+	}
+	if !reindexPackageTypes[strings.ToLower(packageType)] {
+		return nil, nil, fmt.Errorf("reindex not supported for package type [%s]", packageType)
+	}
+	return s.ReindexPackages(ctx, packageType, repoKey, opt)
+}
+
+// ReindexRepository looks up the package type of the repository and triggers the matching metadata calculation.
+func (s *RepositoriesService) ReindexRepository(ctx context.Context, repoKey string, opt *ReindexOptions) (*ReindexResult, *http.Response, error) {
+	repository, resp, err := s.Get(ctx, repoKey)
+	if err != nil {
+		return nil, resp, err
+	}
+	if repository.GetPackageType() == "" {
+		return nil, resp, fmt.Errorf("repository [%s] has no package type", repoKey)
+	}
+	return s.Reindex(ctx, repository.GetPackageType(), repoKey, opt)
+}
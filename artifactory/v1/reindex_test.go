@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReindex(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			assert.Equal(t, "/api/repositories/helm-local", r.URL.Path)
+			_, _ = fmt.Fprint(w, `{"key": "helm-local", "rclass": "local", "packageType": "helm"}`)
+			return
+		}
+		assert.Equal(t, "POST", r.Method)
+		requests = append(requests, r.RequestURI)
+		_, _ = fmt.Fprint(w, "Reindexing scheduled\n")
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	result, _, err := v.Repositories.Reindex(ctx, "rpm", "rpm-local", &ReindexOptions{Path: "centos/7", Async: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "rpm-local", *result.RepoKey)
+	assert.True(t, *result.Async)
+	assert.Equal(t, "Reindexing scheduled", *result.Message)
+
+	_, _, err = v.Repositories.Reindex(ctx, "Debian", "deb-local", &ReindexOptions{WriteProps: true})
+	assert.Nil(t, err)
+	_, _, err = v.Repositories.Reindex(ctx, "maven", "libs-release", &ReindexOptions{Force: true})
+	assert.Nil(t, err)
+	_, _, err = v.Repositories.Reindex(ctx, "npm", "npm-local", nil)
+	assert.Nil(t, err)
+	_, _, err = v.Repositories.ReindexRepository(ctx, "helm-local", nil)
+	assert.Nil(t, err)
+	_, _, err = v.Repositories.Reindex(ctx, "", "unknown", nil)
+	assert.NotNil(t, err)
+	_, _, err = v.Repositories.Reindex(ctx, "Docker", "docker-local", nil)
+	assert.EqualError(t, err, "reindex not supported for package type [Docker]")
+
+	assert.Equal(t, []string{
+		"/api/yum/rpm-local?async=1&path=centos%2F7",
+		"/api/deb/reindex/deb-local?writeProps=1",
+		"/api/maven?force=1&repos=libs-release",
+		"/api/npm/npm-local/reindex",
+		"/api/helm/helm-local/reindex",
+	}, requests)
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultPrefetchConcurrency is the number of parallel requests of a prefetch when no concurrency is given
+const defaultPrefetchConcurrency = 4
+
+// Zaps the cache of a remote repository, or of a path in it: the cached items are expired, so they are checked
+// against the remote on their next request. An empty path zaps the whole repository.
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user (Admin only)
+func (s *RepositoriesService) ZapCache(ctx context.Context, repoKey string, path string) (*http.Response, error) {
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("/api/zap/%s/%s", repoKey, strings.Trim(path, "/")), nil)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("[Artifactory Client] Zap API [%s]", req.URL.String())
+	return s.client.Do(ctx, req, nil)
+}
+
+type PrefetchOptions struct {
+	Concurrency int // Maximum number of parallel requests. Default: 4
+}
+
+// PrefetchFailure is a path that couldn't be fetched through the remote repository
+type PrefetchFailure struct {
+	Path string
+	Err  error
+}
+
+func (f PrefetchFailure) Error() string {
+	return fmt.Sprintf("%s: %v", f.Path, f.Err)
+}
+
+// PrefetchResult is the outcome of a prefetch, the paths are in the order they were requested
+type PrefetchResult struct {
+	Hits     []string // Paths already in the cache
+	Misses   []string // Paths fetched from the remote and now in the cache
+	Failures []PrefetchFailure
+}
+
+// PrefetchRemote warms the cache of a remote repository by requesting the given paths through it. The paths already
+// in the cache are not downloaded again. Failures don't stop the prefetch of the other paths: they are reported in
+// the result, as are the paths left when the context is done.
+// Security: Requires a privileged user (can be anonymous)
+func (s *RepositoriesService) PrefetchRemote(ctx context.Context, repoKey string, paths []string, opt *PrefetchOptions) (*PrefetchResult, error) {
+	concurrency := defaultPrefetchConcurrency
+	if opt != nil && opt.Concurrency > 0 {
+		concurrency = opt.Concurrency
+	}
+
+	const (
+		prefetchHit = iota + 1
+		prefetchMiss
+	)
+	outcomes := make([]int, len(paths))
+	errs := make([]error, len(paths))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				hit, err := s.prefetch(ctx, repoKey, strings.Trim(paths[i], "/"))
+				switch {
+				case err != nil:
+					errs[i] = err
+				case hit:
+					outcomes[i] = prefetchHit
+				default:
+					outcomes[i] = prefetchMiss
+				}
+			}
+		}()
+	}
+dispatch:
+	for i := range paths {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for ; i < len(paths); i++ {
+				errs[i] = ctx.Err()
+			}
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	result := &PrefetchResult{Hits: make([]string, 0), Misses: make([]string, 0), Failures: make([]PrefetchFailure, 0)}
+	for i, p := range paths {
+		switch {
+		case errs[i] != nil:
+			result.Failures = append(result.Failures, PrefetchFailure{Path: p, Err: errs[i]})
+		case outcomes[i] == prefetchHit:
+			result.Hits = append(result.Hits, p)
+		default:
+			result.Misses = append(result.Misses, p)
+		}
+	}
+	return result, nil
+}
+
+// prefetch returns true if the path is already in the cache of the remote repository, otherwise it downloads it
+func (s *RepositoriesService) prefetch(ctx context.Context, repoKey string, path string) (bool, error) {
+	// a plain artifact service: the downloads of the prefetch bypass the download cache and aren't observed
+	artifacts := &ArtifactService{Service: Service(*s)}
+	_, resp, err := artifacts.FileInfo(ctx, repoKey+"-cache", path)
+	if err == nil {
+		return true, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return false, err
+	}
+	_, err = artifacts.download(ctx, repoKey, path, ioutil.Discard)
+	return false, err
+}
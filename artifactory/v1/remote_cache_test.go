@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZapCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/zap/maven-remote/org/example", r.URL.Path)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	_, err := v.Repositories.ZapCache(context.Background(), "maven-remote", "/org/example/")
+	assert.Nil(t, err)
+}
+
+func TestPrefetchRemote(t *testing.T) {
+	var mu sync.Mutex
+	cached := map[string]bool{"a.jar": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/api/storage/maven-remote-cache/a.jar", "/api/storage/maven-remote-cache/b.jar", "/api/storage/maven-remote-cache/c.jar":
+			if cached[r.URL.Path[len("/api/storage/maven-remote-cache/"):]] {
+				_, _ = fmt.Fprint(w, `{"repo": "maven-remote-cache"}`)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case "/maven-remote/b.jar":
+			cached["b.jar"] = true
+			_, _ = fmt.Fprint(w, "content")
+		case "/maven-remote/c.jar":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			t.Errorf("unexpected request [%s]", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	result, err := v.Repositories.PrefetchRemote(context.Background(), "maven-remote", []string{"a.jar", "b.jar", "c.jar"}, &PrefetchOptions{Concurrency: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a.jar"}, result.Hits)
+	assert.Equal(t, []string{"b.jar"}, result.Misses)
+	if assert.Len(t, result.Failures, 1) {
+		assert.Equal(t, "c.jar", result.Failures[0].Path)
+	}
+	assert.True(t, cached["b.jar"])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = v.Repositories.PrefetchRemote(ctx, "maven-remote", []string{"a.jar", "b.jar"}, nil)
+	assert.Nil(t, err)
+	assert.Len(t, result.Failures, 2)
+}
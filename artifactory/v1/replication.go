@@ -0,0 +1,374 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ReplicationStatusNeverRun     = "never_run"
+	ReplicationStatusIncomplete   = "incomplete" // Running or interrupted
+	ReplicationStatusError        = "error"
+	ReplicationStatusWarn         = "warn"
+	ReplicationStatusOk           = "ok"
+	ReplicationStatusInconsistent = "inconsistent"
+
+	// defaultReplicationPollInterval is the interval between two status requests when waiting for a replication
+	defaultReplicationPollInterval = 5 * time.Second
+	// defaultReplicationStartTimeout is the time given to a replication run to be observed when waiting for it
+	defaultReplicationStartTimeout = time.Minute
+)
+
+// ReplicationTarget is a target of an on demand push replication
+type ReplicationTarget struct {
+	URL        *string `json:"url,omitempty"` // Required
+	Username   *string `json:"username,omitempty"`
+	Password   *string `json:"password,omitempty"`
+	Proxy      *string `json:"proxy,omitempty"`
+	Properties *bool   `json:"properties,omitempty"` // Replicate the properties. Default: true
+	Deletes    *bool   `json:"deletes,omitempty"`    // Replicate the deletes. Default: false
+}
+
+func (r ReplicationTarget) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// Schedules an immediate replication of the given repository path. Local repositories are pushed to the given
+// targets, remote repositories are pulled and don't take targets. An empty path replicates the whole repository.
+// Since: 4.7.5
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user
+func (s *ArtifactService) ExecuteReplication(ctx context.Context, repoKey string, path string, targets []ReplicationTarget) (*http.Response, error) {
+	path = fmt.Sprintf("/api/replication/execute/%s/%s", repoKey, strings.Trim(path, "/"))
+	var req *http.Request
+	var err error
+	if len(targets) > 0 {
+		req, err = s.client.NewJSONEncodedRequest("POST", path, targets)
+	} else {
+		req, err = s.client.NewRequest("POST", path, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("[Artifactory Client] Replication API [%s]", req.URL.String())
+	return s.client.Do(ctx, req, nil)
+}
+
+// ReplicationTargetStatus is the status of the replication to a single target, or of a single repository
+type ReplicationTargetStatus struct {
+	URL           *string `json:"url,omitempty"`
+	RepoKey       *string `json:"repoKey,omitempty"`
+	Status        *string `json:"status,omitempty"`        // One of never_run|incomplete|error|warn|ok|inconsistent
+	LastCompleted *string `json:"lastCompleted,omitempty"` // ISO8601 date, or "never"
+}
+
+type ReplicationStatus struct {
+	Status        *string                             `json:"status,omitempty"` // One of never_run|incomplete|error|warn|ok|inconsistent
+	LastCompleted *string                             `json:"lastCompleted,omitempty"`
+	Targets       *[]ReplicationTargetStatus          `json:"targets,omitempty"`
+	Repositories  *map[string]ReplicationTargetStatus `json:"repositories,omitempty"`
+}
+
+func (r ReplicationStatus) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// IsRunning reports whether the replication is in progress, as a whole or for any target or repository
+func (r ReplicationStatus) IsRunning() bool {
+	if stringValue(r.Status) == ReplicationStatusIncomplete {
+		return true
+	}
+	for _, t := range r.allTargets() {
+		if stringValue(t.Status) == ReplicationStatusIncomplete {
+			return true
+		}
+	}
+	return false
+}
+
+// Failures returns the targets, and the repositories of a multi-push replication, whose last replication didn't
+// complete successfully
+func (r ReplicationStatus) Failures() []ReplicationTargetStatus {
+	failures := make([]ReplicationTargetStatus, 0)
+	for _, t := range r.allTargets() {
+		switch stringValue(t.Status) {
+		case ReplicationStatusError, ReplicationStatusWarn, ReplicationStatusInconsistent:
+			failures = append(failures, t)
+		}
+	}
+	return failures
+}
+
+// allTargets returns the targets, then the repositories sorted by key, which are given their key if they lack it
+func (r ReplicationStatus) allTargets() []ReplicationTargetStatus {
+	targets := make([]ReplicationTargetStatus, 0)
+	if r.Targets != nil {
+		targets = append(targets, *r.Targets...)
+	}
+	if r.Repositories != nil {
+		keys := make([]string, 0, len(*r.Repositories))
+		for k := range *r.Repositories {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			t := (*r.Repositories)[k]
+			if t.RepoKey == nil {
+				t.RepoKey = String(k)
+			}
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+// Returns the status of the scheduled replication of a repository, as a whole and per target.
+// Since: 2.4.2
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) GetReplicationStatus(ctx context.Context, repoKey string) (*ReplicationStatus, *http.Response, error) {
+	path := fmt.Sprintf("/api/replication/%s", repoKey)
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	status := new(ReplicationStatus)
+	resp, err := s.client.Do(ctx, req, status)
+	return status, resp, err
+}
+
+type ReplicationWaitOptions struct {
+	PollInterval time.Duration // Interval between two status requests. Default: 5s
+	// Maximum time to observe a replication run after the previous one, see WaitForReplication. Default: 1m
+	StartTimeout time.Duration
+}
+
+// ReplicationNotObservedError is returned by WaitForReplication when no replication run was observed in time
+type ReplicationNotObservedError struct {
+	RepoKey      string
+	StartTimeout time.Duration
+}
+
+func (e *ReplicationNotObservedError) Error() string {
+	return fmt.Sprintf("no replication of [%s] observed within %s: it may not have started, or have ended like the previous one", e.RepoKey, e.StartTimeout)
+}
+
+// WaitForReplication polls the replication status of the repository until no replication is running. When previous is
+// not nil, it also waits for a replication run after the one it reports, so a replication just scheduled isn't
+// missed because it didn't start yet: a run is observed when the replication is seen running, or when the status or
+// the last completion date of the replication or of any of its targets changes. A run which starts and ends between
+// two polls with the same outcome as the previous one leaves no trace, so if no run is observed within the start
+// timeout, the current status is returned with a *ReplicationNotObservedError. It also returns when the context is
+// done.
+func (s *ArtifactService) WaitForReplication(ctx context.Context, repoKey string, previous *ReplicationStatus, opt *ReplicationWaitOptions) (*ReplicationStatus, *http.Response, error) {
+	interval := defaultReplicationPollInterval
+	if opt != nil && opt.PollInterval > 0 {
+		interval = opt.PollInterval
+	}
+	startTimeout := defaultReplicationStartTimeout
+	if opt != nil && opt.StartTimeout > 0 {
+		startTimeout = opt.StartTimeout
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	start := time.Now()
+
+	sawRunning := false
+	for {
+		status, resp, err := s.GetReplicationStatus(ctx, repoKey)
+		if err != nil {
+			return nil, resp, err
+		}
+		if status.IsRunning() {
+			sawRunning = true
+		} else if sawRunning || replicationCompletedSince(status, previous) {
+			return status, resp, nil
+		} else if time.Since(start) >= startTimeout {
+			return status, resp, &ReplicationNotObservedError{RepoKey: repoKey, StartTimeout: startTimeout}
+		}
+		log.Debugf("[Artifactory Client] waiting for the replication of [%s]", repoKey)
+
+		select {
+		case <-ctx.Done():
+			return status, resp, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func replicationCompletedSince(status *ReplicationStatus, previous *ReplicationStatus) bool {
+	if previous == nil {
+		return true
+	}
+	// a failed replication doesn't update the last completed date, but it may change the status of the replication
+	// or of its targets
+	return replicationRunState(*status) != replicationRunState(*previous)
+}
+
+// replicationRunState summarizes the status and last completion dates of the replication and of its targets
+func replicationRunState(r ReplicationStatus) string {
+	state := stringValue(r.Status) + "@" + stringValue(r.LastCompleted)
+	for _, t := range r.allTargets() {
+		state += fmt.Sprintf(";%s|%s|%s@%s", stringValue(t.URL), stringValue(t.RepoKey), stringValue(t.Status), stringValue(t.LastCompleted))
+	}
+	return state
+}
+
+// Replicate executes a replication of the repository path and waits for its completion. See ExecuteReplication and
+// WaitForReplication.
+func (s *ArtifactService) Replicate(ctx context.Context, repoKey string, path string, targets []ReplicationTarget, opt *ReplicationWaitOptions) (*ReplicationStatus, *http.Response, error) {
+	previous, resp, err := s.GetReplicationStatus(ctx, repoKey)
+	if err != nil {
+		return nil, resp, err
+	}
+	if resp, err := s.ExecuteReplication(ctx, repoKey, path, targets); err != nil {
+		return nil, resp, err
+	}
+	return s.WaitForReplication(ctx, repoKey, previous, opt)
+}
+
+// ValidationError lists the problems found by a client side validation
+type ValidationError struct {
+	Subject  string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Subject, strings.Join(e.Problems, "; "))
+}
+
+func (e *ValidationError) addf(format string, a ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, a...))
+}
+
+// errorOrNil returns the error if it has any problem, nil otherwise
+func (e *ValidationError) errorOrNil() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
+
+func validateReplicationCron(v *ValidationError, cronExp *string) {
+	if cronExp == nil || *cronExp == "" {
+		v.addf("cronExp is required")
+	} else if _, err := ParseCronExpression(*cronExp); err != nil {
+		v.addf("%v", err)
+	}
+}
+
+func validateReplicationTarget(v *ValidationError, r SingleReplicationConfig) {
+	target := stringValue(r.URL)
+	switch {
+	case target == "":
+		v.addf("url is required")
+	case !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://"):
+		v.addf("url [%s] is not an http(s) URL", target)
+	}
+	if stringValue(r.Username) == "" {
+		v.addf("username is required for the target [%s]", target)
+	}
+	if r.SocketTimeoutMillis != nil && *r.SocketTimeoutMillis < 0 {
+		v.addf("socketTimeoutMillis of the target [%s] is negative", target)
+	}
+}
+
+// Validate checks the multi-push replication configuration of a local repository: a valid cron expression and at
+// least one target, each with its URL and username.
+func (r ReplicationConfig) Validate() error {
+	v := &ValidationError{Subject: "replication configuration"}
+	validateReplicationCron(v, r.CronExp)
+	if r.Replications == nil || len(*r.Replications) == 0 {
+		v.addf("at least one replication is required")
+		return v
+	}
+	urls := make(map[string]bool)
+	for _, replication := range *r.Replications {
+		validateReplicationTarget(v, replication)
+		if target := stringValue(replication.URL); target != "" {
+			if urls[target] {
+				v.addf("duplicated target [%s]", target)
+			}
+			urls[target] = true
+		}
+	}
+	return v.errorOrNil()
+}
+
+// Validate checks the replication configuration of a repository of the given class: local repositories are pushed
+// to a target whose URL and username are required, remote repositories are pulled from their own URL. Both require
+// a valid cron expression.
+func (r SingleReplicationConfig) Validate(repoClass string) error {
+	v := &ValidationError{Subject: "replication configuration"}
+	validateReplicationCron(v, r.CronExp)
+	switch repoClass {
+	case RepositoryClassLocal:
+		validateReplicationTarget(v, r)
+	case RepositoryClassRemote:
+		if r.SocketTimeoutMillis != nil && *r.SocketTimeoutMillis < 0 {
+			v.addf("socketTimeoutMillis is negative")
+		}
+	default:
+		v.addf("replication isn't supported by [%s] repositories", repoClass)
+	}
+	return v.errorOrNil()
+}
+
+type ReplicationValidationOptions struct {
+	VerifyTargets bool // Verifies the connection from Artifactory to each push target
+}
+
+// ValidateRepositoryReplicationConfig validates a multi-push replication configuration before it's set, optionally
+// verifying that Artifactory can connect to each target.
+func (s *ArtifactService) ValidateRepositoryReplicationConfig(ctx context.Context, config *ReplicationConfig, opt *ReplicationValidationOptions) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if opt == nil || !opt.VerifyTargets {
+		return nil
+	}
+	return s.verifyReplicationTargets(ctx, *config.Replications)
+}
+
+// ValidateSingleRepositoryReplicationConfig validates the replication configuration of a repository before it's set,
+// according to the class of the repository. Push targets can optionally be verified.
+func (s *ArtifactService) ValidateSingleRepositoryReplicationConfig(ctx context.Context, repoKey string, config *SingleReplicationConfig, opt *ReplicationValidationOptions) error {
+	repository, _, err := (*RepositoriesService)(&s.Service).Get(ctx, repoKey)
+	if err != nil {
+		return err
+	}
+	repoClass := repository.GetRClass()
+	if err := config.Validate(repoClass); err != nil {
+		return err
+	}
+	if opt == nil || !opt.VerifyTargets || repoClass != RepositoryClassLocal {
+		return nil
+	}
+	return s.verifyReplicationTargets(ctx, []SingleReplicationConfig{*config})
+}
+
+func (s *ArtifactService) verifyReplicationTargets(ctx context.Context, replications []SingleReplicationConfig) error {
+	v := &ValidationError{Subject: "replication targets"}
+	for _, r := range replications {
+		_, _, err := (*SystemService)(&s.Service).VerifyConnection(ctx, &VerifyConnectionOptions{
+			Endpoint: r.URL,
+			Username: r.Username,
+			Password: r.Password,
+		})
+		if err != nil {
+			v.addf("target [%s] is unreachable: %v", stringValue(r.URL), err)
+		}
+	}
+	return v.errorOrNil()
+}
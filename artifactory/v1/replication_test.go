@@ -0,0 +1,196 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicate(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/replication/execute/libs-local/org/example":
+			body, _ := ioutil.ReadAll(r.Body)
+			var targets []ReplicationTarget
+			assert.Nil(t, json.Unmarshal(body, &targets))
+			if assert.Len(t, targets, 1) {
+				assert.Equal(t, "https://target/artifactory/libs-local", *targets[0].URL)
+			}
+		case r.Method == "GET" && r.URL.Path == "/api/replication/libs-local":
+			switch atomic.AddInt32(&polls, 1) {
+			case 1, 2:
+				// the status before the replication, then the replication not started yet
+				_, _ = fmt.Fprint(w, `{"status": "ok", "lastCompleted": "2020-01-01T00:00:00.000Z"}`)
+			case 3:
+				_, _ = fmt.Fprint(w, `{"status": "incomplete", "lastCompleted": "2020-01-01T00:00:00.000Z"}`)
+			default:
+				_, _ = fmt.Fprint(w, `{"status": "error", "lastCompleted": "2020-01-01T00:00:00.000Z",
+					"targets": [{"url": "https://target/artifactory/libs-local", "repoKey": "libs-local", "status": "error", "lastCompleted": "never"}]}`)
+			}
+		default:
+			t.Errorf("unexpected request [%s %s]", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	targets := []ReplicationTarget{{URL: String("https://target/artifactory/libs-local")}}
+	status, _, err := v.Artifacts.Replicate(context.Background(), "libs-local", "/org/example", targets, &ReplicationWaitOptions{PollInterval: time.Millisecond})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&polls))
+	assert.Equal(t, ReplicationStatusError, *status.Status)
+	if failures := status.Failures(); assert.Len(t, failures, 1) {
+		assert.Equal(t, "libs-local", *failures[0].RepoKey)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err = v.Artifacts.WaitForReplication(ctx, "libs-local", status, &ReplicationWaitOptions{PollInterval: time.Millisecond})
+	assert.NotNil(t, err)
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+
+	// the run isn't observed, as it would end like the previous one
+	status, _, err = v.Artifacts.WaitForReplication(context.Background(), "libs-local", status, &ReplicationWaitOptions{
+		PollInterval: time.Millisecond,
+		StartTimeout: 20 * time.Millisecond,
+	})
+	assert.IsType(t, &ReplicationNotObservedError{}, err)
+	assert.Equal(t, ReplicationStatusError, *status.Status)
+}
+
+func TestReplicateFailingAgain(t *testing.T) {
+	failed := `{"status": "error", "lastCompleted": "2020-01-01T00:00:00.000Z",
+		"targets": [{"url": "https://target/artifactory/libs-local", "status": "error", "lastCompleted": "never"}]}`
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			return
+		}
+		switch atomic.AddInt32(&polls, 1) {
+		case 2, 3:
+			_, _ = fmt.Fprint(w, `{"status": "error", "lastCompleted": "2020-01-01T00:00:00.000Z",
+				"targets": [{"url": "https://target/artifactory/libs-local", "status": "incomplete", "lastCompleted": "never"}]}`)
+		default:
+			// the status before the replication, then after a replication failing the same way
+			_, _ = fmt.Fprint(w, failed)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	status, _, err := v.Artifacts.Replicate(ctx, "libs-local", "", nil, &ReplicationWaitOptions{PollInterval: time.Millisecond})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&polls))
+	assert.Equal(t, ReplicationStatusError, *status.Status)
+	assert.Len(t, status.Failures(), 1)
+}
+
+func TestReplicationStatusFailures(t *testing.T) {
+	status := new(ReplicationStatus)
+	assert.Nil(t, json.Unmarshal([]byte(`{"status": "error", "targets": [{"url": "https://a", "status": "ok"}],
+		"repositories": {"libs-b": {"status": "warn"}, "libs-a": {"status": "error"}, "libs-c": {"status": "incomplete"}}}`), status))
+	failures := status.Failures()
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, "libs-a", *failures[0].RepoKey)
+		assert.Equal(t, "libs-b", *failures[1].RepoKey)
+	}
+	assert.True(t, status.IsRunning())
+}
+
+func TestGetRepositoryReplicationConfig(t *testing.T) {
+	body := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/replications/libs-local", r.URL.Path)
+		_, _ = fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	body = `{"repoKey": "libs-local", "url": "https://a", "cronExp": "0 0 * * * ?"}`
+	config, _, err := v.Artifacts.GetRepositoryReplicationConfig(context.Background(), "libs-local")
+	assert.Nil(t, err)
+	assert.Equal(t, "0 0 * * * ?", *config.CronExp)
+	assert.Len(t, *config.Replications, 1)
+
+	body = `[{"repoKey": "libs-local", "url": "https://a"}, {"repoKey": "libs-local", "url": "https://b"}]`
+	config, _, err = v.Artifacts.GetRepositoryReplicationConfig(context.Background(), "libs-local")
+	assert.Nil(t, err)
+	if assert.Len(t, *config.Replications, 2) {
+		assert.Equal(t, "https://b", *(*config.Replications)[1].URL)
+	}
+}
+
+func TestValidateReplicationConfig(t *testing.T) {
+	config := ReplicationConfig{
+		CronExp: String("0 0 * * * ?"),
+		Replications: &[]SingleReplicationConfig{
+			{URL: String("https://a/artifactory/libs-local"), Username: String("admin")},
+		},
+	}
+	assert.Nil(t, config.Validate())
+
+	config.CronExp = String("0 0 * * *")
+	*config.Replications = append(*config.Replications,
+		SingleReplicationConfig{URL: String("https://a/artifactory/libs-local")},
+		SingleReplicationConfig{URL: String("ftp://b"), Username: String("admin")})
+	err := config.Validate()
+	if assert.IsType(t, &ValidationError{}, err) {
+		assert.Len(t, err.(*ValidationError).Problems, 4)
+	}
+
+	pull := SingleReplicationConfig{CronExp: String("0 0 * * * ?")}
+	assert.Nil(t, pull.Validate("remote"))
+	assert.NotNil(t, pull.Validate("local"))
+	assert.NotNil(t, pull.Validate("virtual"))
+}
+
+func TestValidateSingleRepositoryReplicationConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/repositories/libs-local":
+			_, _ = fmt.Fprint(w, `{"key": "libs-local", "rclass": "local"}`)
+		case "/api/system/verifyconnection":
+			body, _ := ioutil.ReadAll(r.Body)
+			var opt VerifyConnectionOptions
+			assert.Nil(t, json.Unmarshal(body, &opt))
+			if *opt.Endpoint != "https://ok/artifactory/libs-local" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `{"errors": [{"status": 400, "message": "Connection refused"}]}`)
+			}
+		default:
+			t.Errorf("unexpected request [%s]", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	opt := &ReplicationValidationOptions{VerifyTargets: true}
+
+	config := &SingleReplicationConfig{CronExp: String("0 0 * * * ?"), URL: String("https://ok/artifactory/libs-local"), Username: String("admin")}
+	assert.Nil(t, v.Artifacts.ValidateSingleRepositoryReplicationConfig(context.Background(), "libs-local", config, opt))
+
+	config.URL = String("https://ko/artifactory/libs-local")
+	err := v.Artifacts.ValidateSingleRepositoryReplicationConfig(context.Background(), "libs-local", config, opt)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Connection refused")
+	}
+}
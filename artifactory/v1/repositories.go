@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
 )
 
 type RepositoriesService Service
@@ -24,7 +26,7 @@ func (r RepositoryDetails) String() string {
 
 type RepositoryListOptions struct {
 	// Type of repositories to list.
-	// Can be one of local|remote|virtual|distribution. Default: all
+	// Can be one of local|remote|virtual|distribution|federated. Default: all
 	Type string `url:"type,omitempty"`
 }
 
@@ -88,6 +90,8 @@ type LocalRepository struct {
 	XrayIndex                    *bool     `json:"xrayIndex,omitempty"`
 	XrayMinimumBlockedSeverity   *string   `json:"xrayMinimumBlockedSeverity,omitempty"`
 	YumRootDepth                 *int      `json:"yumRootDepth,omitempty"`
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
 }
 
 func (r LocalRepository) String() string {
@@ -95,6 +99,22 @@ func (r LocalRepository) String() string {
 	return string(res)
 }
 
+// GetUnknownFields returns the attributes returned by Artifactory which LocalRepository doesn't model, sent back
+// unchanged on update.
+func (r LocalRepository) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r LocalRepository) MarshalJSON() ([]byte, error) {
+	type localRepository LocalRepository
+	return client.MarshalWithUnknownFields(localRepository(r), r.unknownFields)
+}
+
+func (r *LocalRepository) UnmarshalJSON(data []byte) error {
+	type localRepository LocalRepository
+	unknown, err := client.UnmarshalKnownFields(data, (*localRepository)(r))
+	r.unknownFields = unknown
+	return err
+}
+
 // Creates a new repository in Artifactory with the provided configuration.
 // Since: 2.3.0
 // Notes: Requires Artifactory Pro
@@ -239,6 +259,8 @@ type RemoteRepository struct {
 	FeedContextPath     *string `json:"feedContextPath,omitempty"`
 	DownloadContextPath *string `json:"downloadContextPath,omitempty"`
 	V3FeedUrl           *string `json:"v3FeedUrl,omitempty"`
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
 }
 
 func (r RemoteRepository) String() string {
@@ -246,6 +268,22 @@ func (r RemoteRepository) String() string {
 	return string(res)
 }
 
+// GetUnknownFields returns the attributes returned by Artifactory which RemoteRepository doesn't model, sent back
+// unchanged on update.
+func (r RemoteRepository) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r RemoteRepository) MarshalJSON() ([]byte, error) {
+	type remoteRepository RemoteRepository
+	return client.MarshalWithUnknownFields(remoteRepository(r), r.unknownFields)
+}
+
+func (r *RemoteRepository) UnmarshalJSON(data []byte) error {
+	type remoteRepository RemoteRepository
+	unknown, err := client.UnmarshalKnownFields(data, (*remoteRepository)(r))
+	r.unknownFields = unknown
+	return err
+}
+
 // Creates a new repository in Artifactory with the provided configuration.
 // Since: 2.3.0
 // Notes: Requires Artifactory Pro
@@ -319,6 +357,8 @@ type VirtualRepository struct {
 	PomRepositoryReferencesCleanupPolicy          *string   `json:"pomRepositoryReferencesCleanupPolicy,omitempty"`
 	Repositories                                  *[]string `json:"repositories,omitempty"`
 	VirtualRetrievalCachePeriodSecs               *int      `json:"virtualRetrievalCachePeriodSecs,omitempty"`
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
 }
 
 func (r VirtualRepository) String() string {
@@ -326,6 +366,22 @@ func (r VirtualRepository) String() string {
 	return string(res)
 }
 
+// GetUnknownFields returns the attributes returned by Artifactory which VirtualRepository doesn't model, sent back
+// unchanged on update.
+func (r VirtualRepository) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r VirtualRepository) MarshalJSON() ([]byte, error) {
+	type virtualRepository VirtualRepository
+	return client.MarshalWithUnknownFields(virtualRepository(r), r.unknownFields)
+}
+
+func (r *VirtualRepository) UnmarshalJSON(data []byte) error {
+	type virtualRepository VirtualRepository
+	unknown, err := client.UnmarshalKnownFields(data, (*virtualRepository)(r))
+	r.unknownFields = unknown
+	return err
+}
+
 // Creates a new repository in Artifactory with the provided configuration.
 // Since: 2.3.0
 // Notes: Requires Artifactory Pro
@@ -365,6 +421,373 @@ func (s *RepositoriesService) DeleteVirtual(ctx context.Context, repo string) (*
 	return s.delete(ctx, repo)
 }
 
+const (
+	RepositoryClassLocal        = "local"
+	RepositoryClassRemote       = "remote"
+	RepositoryClassVirtual      = "virtual"
+	RepositoryClassDistribution = "distribution"
+	RepositoryClassFederated    = "federated"
+)
+
+// Repository is the configuration of a repository of any class, as returned by Get
+type Repository interface {
+	GetKey() string
+	GetRClass() string
+	GetPackageType() string
+	GetUnknownFields() client.UnknownFields
+}
+
+func (r LocalRepository) GetKey() string         { return stringValue(r.Key) }
+func (r LocalRepository) GetRClass() string      { return stringValue(r.RClass) }
+func (r LocalRepository) GetPackageType() string { return stringValue(r.PackageType) }
+
+func (r RemoteRepository) GetKey() string         { return stringValue(r.Key) }
+func (r RemoteRepository) GetRClass() string      { return stringValue(r.RClass) }
+func (r RemoteRepository) GetPackageType() string { return stringValue(r.PackageType) }
+
+func (r VirtualRepository) GetKey() string         { return stringValue(r.Key) }
+func (r VirtualRepository) GetRClass() string      { return stringValue(r.RClass) }
+func (r VirtualRepository) GetPackageType() string { return stringValue(r.PackageType) }
+
+// DistributionCoordinates are the templates of the location of an artifact in the distribution target. They can use
+// the ${repo}, ${path} and ${name} tokens, the properties of the artifact as ${property.name}, and the tokens of the
+// repository layout like ${groupId}, ${artifactId}, ${version} or ${module}.
+type DistributionCoordinates struct {
+	Repo    *string `json:"repo,omitempty"`
+	Pkg     *string `json:"pkg,omitempty"`
+	Version *string `json:"version,omitempty"`
+	Path    *string `json:"path,omitempty"`
+}
+
+type DistributionRule struct {
+	Name                    *string                  `json:"name,omitempty"`
+	Type                    *string                  `json:"type,omitempty"`       // Package type of the artifacts the rule applies to
+	RepoFilter              *string                  `json:"repoFilter,omitempty"` // Pattern of the source repositories
+	PathFilter              *string                  `json:"pathFilter,omitempty"` // Pattern of the paths in the source repositories
+	DistributionCoordinates *DistributionCoordinates `json:"distributionCoordinates,omitempty"`
+}
+
+// application/vnd.org.jfrog.artifactory.repositories.DistributionRepositoryConfiguration+json
+type DistributionRepository struct {
+	Key                    *string             `json:"key,omitempty"`
+	RClass                 *string             `json:"rclass,omitempty"` // Mandatory element in create/replace queries (optional in "update" queries)
+	PackageType            *string             `json:"packageType,omitempty"`
+	Description            *string             `json:"description,omitempty"`
+	Notes                  *string             `json:"notes,omitempty"`
+	IncludesPattern        *string             `json:"includesPattern,omitempty"`
+	ExcludesPattern        *string             `json:"excludesPattern,omitempty"`
+	RepoLayoutRef          *string             `json:"repoLayoutRef,omitempty"`
+	DistributionProvider   *string             `json:"distributionProvider,omitempty"`
+	BintrayApplication     *string             `json:"bintrayApplication,omitempty"`
+	DockerRepository       *string             `json:"dockerRepository,omitempty"`
+	Proxy                  *string             `json:"proxy,omitempty"`
+	DefaultNewRepoPrivate  *bool               `json:"defaultNewRepoPrivate,omitempty"`
+	DefaultNewRepoPremium  *bool               `json:"defaultNewRepoPremium,omitempty"`
+	DefaultLicenses        *[]string           `json:"defaultLicenses,omitempty"`
+	DefaultVcsUrl          *string             `json:"defaultVcsUrl,omitempty"`
+	Rules                  *[]DistributionRule `json:"rules,omitempty"`
+	WhiteListedProperties  *[]string           `json:"whiteListedProperties,omitempty"`
+	GpgSign                *bool               `json:"gpgSign,omitempty"`
+	GpgPassPhrase          *string             `json:"gpgPassPhrase,omitempty"`
+	ProductName            *string             `json:"productName,omitempty"`
+	BlackedOut             *bool               `json:"blackedOut,omitempty"`
+	DownloadRedirect       *bool               `json:"downloadRedirect,omitempty"`
+	XrayIndex              *bool               `json:"xrayIndex,omitempty"`
+	ArchiveBrowsingEnabled *bool               `json:"archiveBrowsingEnabled,omitempty"`
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
+}
+
+func (r DistributionRepository) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// GetUnknownFields returns the attributes returned by Artifactory which DistributionRepository doesn't model, sent back
+// unchanged on update.
+func (r DistributionRepository) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r DistributionRepository) MarshalJSON() ([]byte, error) {
+	type distributionRepository DistributionRepository
+	return client.MarshalWithUnknownFields(distributionRepository(r), r.unknownFields)
+}
+
+func (r *DistributionRepository) UnmarshalJSON(data []byte) error {
+	type distributionRepository DistributionRepository
+	unknown, err := client.UnmarshalKnownFields(data, (*distributionRepository)(r))
+	r.unknownFields = unknown
+	return err
+}
+
+// Creates a new distribution repository in Artifactory with the provided configuration.
+// Since: 4.8.0
+// Notes: Requires Artifactory Pro
+// An existing repository with the same key are removed from the configuration and its content is removed!
+// Security: Requires an admin user
+func (s *RepositoriesService) CreateDistribution(ctx context.Context, repo *DistributionRepository) (*http.Response, error) {
+	return s.create(ctx, *repo.Key, repo)
+}
+
+// Retrieves the current configuration of a distribution repository.
+// Since: 4.8.0
+// Notes: Requires Artifactory Pro
+// Security: Requires an admin user for complete repository configuration. Non-admin users will receive only partial configuration data.
+func (s *RepositoriesService) GetDistribution(ctx context.Context, repo string) (*DistributionRepository, *http.Response, error) {
+	repository, resp, err := s.get(ctx, repo, new(DistributionRepository))
+	if err != nil {
+		return nil, resp, err
+	}
+	return repository.(*DistributionRepository), resp, nil
+}
+
+// Updates an exiting distribution repository configuration in Artifactory with the provided configuration elements.
+// Since: 4.8.0
+// Notes: Requires Artifactory Pro
+// Security: Requires an admin user
+func (s *RepositoriesService) UpdateDistribution(ctx context.Context, repo string, repository *DistributionRepository) (*http.Response, error) {
+	return s.update(ctx, repo, repository)
+}
+
+// Removes a distribution repository configuration together with the whole repository content.
+// Since: 4.8.0
+// Notes: Requires Artifactory Pro
+// Security: Requires an admin user
+func (s *RepositoriesService) DeleteDistribution(ctx context.Context, repo string) (*http.Response, error) {
+	return s.delete(ctx, repo)
+}
+
+func (r DistributionRepository) GetKey() string         { return stringValue(r.Key) }
+func (r DistributionRepository) GetRClass() string      { return stringValue(r.RClass) }
+func (r DistributionRepository) GetPackageType() string { return stringValue(r.PackageType) }
+
+type FederatedMember struct {
+	Url     *string `json:"url,omitempty"` // URL of the member repository, like https://host/artifactory/repo-key
+	Enabled *bool   `json:"enabled,omitempty"`
+}
+
+// application/vnd.org.jfrog.artifactory.repositories.FederatedRepositoryConfiguration+json
+type FederatedRepository struct {
+	Key                          *string            `json:"key,omitempty"`
+	RClass                       *string            `json:"rclass,omitempty"` // Mandatory element in create/replace queries (optional in "update" queries)
+	PackageType                  *string            `json:"packageType,omitempty"`
+	Description                  *string            `json:"description,omitempty"`
+	Notes                        *string            `json:"notes,omitempty"`
+	IncludesPattern              *string            `json:"includesPattern,omitempty"`
+	ExcludesPattern              *string            `json:"excludesPattern,omitempty"`
+	ArchiveBrowsingEnabled       *bool              `json:"archiveBrowsingEnabled,omitempty"`
+	BlackedOut                   *bool              `json:"blackedOut,omitempty"`
+	BlockXrayUnscannedArtifacts  *bool              `json:"blockXrayUnscannedArtifacts,omitempty"`
+	CalculateYumMetadata         *bool              `json:"calculateYumMetadata,omitempty"`
+	ChecksumPolicyType           *string            `json:"checksumPolicyType,omitempty"`
+	DebianTrivialLayout          *bool              `json:"debianTrivialLayout,omitempty"`
+	DockerApiVersion             *string            `json:"dockerApiVersion,omitempty"`
+	EnableBowerSupport           *bool              `json:"enableBowerSupport,omitempty"`
+	EnableCocoaPodsSupport       *bool              `json:"enableCocoaPodsSupport,omitempty"`
+	EnableComposerSupport        *bool              `json:"enableComposerSupport,omitempty"`
+	EnableConanSupport           *bool              `json:"enableConanSupport,omitempty"`
+	EnableDebianSupport          *bool              `json:"enableDebianSupport,omitempty"`
+	EnableDistRepoSupport        *bool              `json:"enableDistRepoSupport,omitempty"`
+	EnableDockerSupport          *bool              `json:"enableDockerSupport,omitempty"`
+	EnableFileListsIndexing      *bool              `json:"enableFileListsIndexing,omitempty"`
+	EnableGemsSupport            *bool              `json:"enableGemsSupport,omitempty"`
+	EnableGitLfsSupport          *bool              `json:"enableGitLfsSupport,omitempty"`
+	EnableNpmSupport             *bool              `json:"enableNpmSupport,omitempty"`
+	EnableNuGetSupport           *bool              `json:"enableNuGetSupport,omitempty"`
+	EnablePuppetSupport          *bool              `json:"enablePuppetSupport,omitempty"`
+	EnablePypiSupport            *bool              `json:"enablePypiSupport,omitempty"`
+	EnableVagrantSupport         *bool              `json:"enableVagrantSupport,omitempty"`
+	EnabledChefSupport           *bool              `json:"enabledChefSupport,omitempty"`
+	ForceNugetAuthentication     *bool              `json:"forceNugetAuthentication,omitempty"`
+	HandleReleases               *bool              `json:"handleReleases,omitempty"`
+	HandleSnapshots              *bool              `json:"handleSnapshots,omitempty"`
+	MaxUniqueSnapshots           *int               `json:"maxUniqueSnapshots,omitempty"`
+	MaxUniqueTags                *int               `json:"maxUniqueTags,omitempty"`
+	PropertySets                 *[]string          `json:"propertySets,omitempty"`
+	RepoLayoutRef                *string            `json:"repoLayoutRef,omitempty"`
+	SnapshotVersionBehavior      *string            `json:"snapshotVersionBehavior,omitempty"`
+	SuppressPomConsistencyChecks *bool              `json:"suppressPomConsistencyChecks,omitempty"`
+	XrayIndex                    *bool              `json:"xrayIndex,omitempty"`
+	XrayMinimumBlockedSeverity   *string            `json:"xrayMinimumBlockedSeverity,omitempty"`
+	YumRootDepth                 *int               `json:"yumRootDepth,omitempty"`
+	Members                      *[]FederatedMember `json:"members,omitempty"`
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
+}
+
+func (r FederatedRepository) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// GetUnknownFields returns the attributes returned by Artifactory which FederatedRepository doesn't model, sent back
+// unchanged on update.
+func (r FederatedRepository) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r FederatedRepository) MarshalJSON() ([]byte, error) {
+	type federatedRepository FederatedRepository
+	return client.MarshalWithUnknownFields(federatedRepository(r), r.unknownFields)
+}
+
+func (r *FederatedRepository) UnmarshalJSON(data []byte) error {
+	type federatedRepository FederatedRepository
+	unknown, err := client.UnmarshalKnownFields(data, (*federatedRepository)(r))
+	r.unknownFields = unknown
+	return err
+}
+
+// Creates a new federated repository in Artifactory with the provided configuration. The members are federated with
+// the new repository, they are created if they don't exist.
+// Since: 7.18.3
+// Notes: Requires an Enterprise+ license
+// Security: Requires an admin user
+func (s *RepositoriesService) CreateFederated(ctx context.Context, repo *FederatedRepository) (*http.Response, error) {
+	return s.create(ctx, *repo.Key, repo)
+}
+
+// Retrieves the current configuration of a federated repository.
+// Since: 7.18.3
+// Notes: Requires an Enterprise+ license
+// Security: Requires an admin user for complete repository configuration. Non-admin users will receive only partial configuration data.
+func (s *RepositoriesService) GetFederated(ctx context.Context, repo string) (*FederatedRepository, *http.Response, error) {
+	repository, resp, err := s.get(ctx, repo, new(FederatedRepository))
+	if err != nil {
+		return nil, resp, err
+	}
+	return repository.(*FederatedRepository), resp, nil
+}
+
+// Updates an exiting federated repository configuration in Artifactory with the provided configuration elements.
+// Since: 7.18.3
+// Notes: Requires an Enterprise+ license
+// Security: Requires an admin user
+func (s *RepositoriesService) UpdateFederated(ctx context.Context, repo string, repository *FederatedRepository) (*http.Response, error) {
+	return s.update(ctx, repo, repository)
+}
+
+// Removes a federated repository configuration together with the whole repository content. The other members are
+// not removed.
+// Since: 7.18.3
+// Notes: Requires an Enterprise+ license
+// Security: Requires an admin user
+func (s *RepositoriesService) DeleteFederated(ctx context.Context, repo string) (*http.Response, error) {
+	return s.delete(ctx, repo)
+}
+
+// Converts a local repository to a federated repository without members, keeping its content.
+// Since: 7.18.3
+// Notes: Requires an Enterprise+ license
+// Security: Requires an admin user
+func (s *RepositoriesService) ConvertLocalToFederated(ctx context.Context, repo string) (*http.Response, error) {
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("/api/federation/migrate/%s", repo), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+type FederationSyncOptions struct {
+	Mirror string `url:"mirror,omitempty"` // URL of the member to synchronize. Default: all the members
+}
+
+// Triggers the full synchronization of a federated repository with its members.
+// Since: 7.18.3
+// Notes: Requires an Enterprise+ license
+// Security: Requires an admin user
+func (s *RepositoriesService) FederationFullSync(ctx context.Context, repo string, opt *FederationSyncOptions) (*http.Response, error) {
+	path, err := client.AddOptions(fmt.Sprintf("/api/federation/fullSync/%s", repo), opt)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+func (r FederatedRepository) GetKey() string         { return stringValue(r.Key) }
+func (r FederatedRepository) GetRClass() string      { return stringValue(r.RClass) }
+func (r FederatedRepository) GetPackageType() string { return stringValue(r.PackageType) }
+
+// Retrieves the current configuration of a repository, whatever its class. The configuration is one of
+// *LocalRepository, *RemoteRepository, *VirtualRepository, *DistributionRepository and *FederatedRepository,
+// according to its rclass.
+// Since: 2.3.0
+// Security: Requires an admin user for complete repository configuration. Non-admin users will receive only partial configuration data.
+func (s *RepositoriesService) Get(ctx context.Context, repo string) (Repository, *http.Response, error) {
+	raw, resp, err := s.get(ctx, repo, new(json.RawMessage))
+	if err != nil {
+		return nil, resp, err
+	}
+	repository, err := DecodeRepository(*raw.(*json.RawMessage))
+	if err != nil {
+		return nil, resp, err
+	}
+	return repository, resp, nil
+}
+
+// DecodeRepository decodes the JSON configuration of a repository into the type of its rclass, one of
+// *LocalRepository, *RemoteRepository, *VirtualRepository, *DistributionRepository and *FederatedRepository.
+func DecodeRepository(data []byte) (Repository, error) {
+	var probe struct {
+		Key    *string `json:"key,omitempty"`
+		RClass *string `json:"rclass,omitempty"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	switch rclass := stringValue(probe.RClass); rclass {
+	case RepositoryClassLocal:
+		repository = new(LocalRepository)
+	case RepositoryClassRemote:
+		repository = new(RemoteRepository)
+	case RepositoryClassVirtual:
+		repository = new(VirtualRepository)
+	case RepositoryClassDistribution:
+		repository = new(DistributionRepository)
+	case RepositoryClassFederated:
+		repository = new(FederatedRepository)
+	default:
+		return nil, fmt.Errorf("unsupported class [%s] of repository [%s]", rclass, stringValue(probe.Key))
+	}
+	if err := json.Unmarshal(data, repository); err != nil {
+		return nil, err
+	}
+	return repository, nil
+}
+
+// AsLocal returns the configuration of a local repository, false if the repository is of another class
+func AsLocal(r Repository) (*LocalRepository, bool) {
+	repository, ok := r.(*LocalRepository)
+	return repository, ok
+}
+
+// AsRemote returns the configuration of a remote repository, false if the repository is of another class
+func AsRemote(r Repository) (*RemoteRepository, bool) {
+	repository, ok := r.(*RemoteRepository)
+	return repository, ok
+}
+
+// AsVirtual returns the configuration of a virtual repository, false if the repository is of another class
+func AsVirtual(r Repository) (*VirtualRepository, bool) {
+	repository, ok := r.(*VirtualRepository)
+	return repository, ok
+}
+
+// AsDistribution returns the configuration of a distribution repository, false if the repository is of another class
+func AsDistribution(r Repository) (*DistributionRepository, bool) {
+	repository, ok := r.(*DistributionRepository)
+	return repository, ok
+}
+
+// AsFederated returns the configuration of a federated repository, false if the repository is of another class
+func AsFederated(r Repository) (*FederatedRepository, bool) {
+	repository, ok := r.(*FederatedRepository)
+	return repository, ok
+}
+
 // Generic repo CRUD operations
 func (s *RepositoriesService) create(ctx context.Context, repo string, v interface{}) (*http.Response, error) {
 	path := fmt.Sprintf("/api/repositories/%s", repo)
@@ -383,7 +806,8 @@ func (s *RepositoriesService) get(ctx context.Context, repo string, v interface{
 		return nil, nil, err
 	}
 
-	acceptHeaders := []string{mediaTypeLocalRepository, mediaTypeVirtualRepository, mediaTypeRemoteRepository}
+	acceptHeaders := []string{mediaTypeLocalRepository, mediaTypeVirtualRepository, mediaTypeRemoteRepository,
+		mediaTypeDistributionRepository, mediaTypeFederatedRepository}
 	req.Header.Set("Accept", strings.Join(acceptHeaders, ", "))
 
 	resp, err := s.client.Do(ctx, req, v)
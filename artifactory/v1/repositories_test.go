@@ -0,0 +1,274 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/api/repositories/")
+		assert.Contains(t, r.Header.Get("Accept"), mediaTypeDistributionRepository)
+		assert.Contains(t, r.Header.Get("Accept"), mediaTypeFederatedRepository)
+		switch key {
+		case "libs-local":
+			_, _ = fmt.Fprint(w, `{"key": "libs-local", "rclass": "local", "packageType": "maven", "handleSnapshots": false}`)
+		case "maven-remote":
+			_, _ = fmt.Fprint(w, `{"key": "maven-remote", "rclass": "remote", "packageType": "maven", "url": "https://repo1.maven.org/maven2"}`)
+		case "libs":
+			_, _ = fmt.Fprint(w, `{"key": "libs", "rclass": "virtual", "packageType": "maven", "repositories": ["libs-local", "maven-remote"]}`)
+		case "releases":
+			_, _ = fmt.Fprint(w, `{"key": "releases", "rclass": "distribution", "packageType": "generic"}`)
+		case "shared":
+			_, _ = fmt.Fprint(w, `{"key": "shared", "rclass": "federated", "packageType": "npm"}`)
+		case "unknown":
+			_, _ = fmt.Fprint(w, `{"key": "unknown", "rclass": "unknown"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	repository, _, err := v.Repositories.Get(ctx, "libs-local")
+	assert.Nil(t, err)
+	local, ok := AsLocal(repository)
+	if assert.True(t, ok) {
+		assert.False(t, *local.HandleSnapshots)
+	}
+	assert.Equal(t, "libs-local", repository.GetKey())
+	assert.Equal(t, RepositoryClassLocal, repository.GetRClass())
+	assert.Equal(t, "maven", repository.GetPackageType())
+	_, ok = AsRemote(repository)
+	assert.False(t, ok)
+
+	repository, _, err = v.Repositories.Get(ctx, "maven-remote")
+	assert.Nil(t, err)
+	if remote, ok := AsRemote(repository); assert.True(t, ok) {
+		assert.Equal(t, "https://repo1.maven.org/maven2", *remote.Url)
+	}
+
+	repository, _, err = v.Repositories.Get(ctx, "libs")
+	assert.Nil(t, err)
+	if virtual, ok := AsVirtual(repository); assert.True(t, ok) {
+		assert.Equal(t, []string{"libs-local", "maven-remote"}, *virtual.Repositories)
+	}
+
+	repository, _, err = v.Repositories.Get(ctx, "releases")
+	assert.Nil(t, err)
+	_, ok = AsDistribution(repository)
+	assert.True(t, ok)
+
+	repository, _, err = v.Repositories.Get(ctx, "shared")
+	assert.Nil(t, err)
+	_, ok = AsFederated(repository)
+	assert.True(t, ok)
+
+	_, _, err = v.Repositories.Get(ctx, "unknown")
+	assert.NotNil(t, err)
+	_, resp, err := v.Repositories.Get(ctx, "missing")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestFederatedRepository(t *testing.T) {
+	stored := make(map[string][]byte)
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		key := strings.TrimPrefix(r.URL.Path, "/api/repositories/")
+		switch {
+		case r.Method == "PUT" || (r.Method == "POST" && strings.HasPrefix(r.URL.Path, "/api/repositories/")):
+			body, _ := ioutil.ReadAll(r.Body)
+			stored[key] = body
+		case r.Method == "GET":
+			if body, ok := stored[key]; ok {
+				_, _ = w.Write(body)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "DELETE":
+			delete(stored, key)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	repo := &FederatedRepository{
+		Key:         String("npm-federated"),
+		RClass:      String(RepositoryClassFederated),
+		PackageType: String("npm"),
+		Description: String("shared npm packages"),
+		XrayIndex:   Bool(true),
+		Members: &[]FederatedMember{
+			{Url: String("https://site-a/artifactory/npm-federated"), Enabled: Bool(true)},
+			{Url: String("https://site-b/artifactory/npm-federated"), Enabled: Bool(false)},
+		},
+	}
+	_, err := v.Repositories.CreateFederated(ctx, repo)
+	assert.Nil(t, err)
+
+	fetched, _, err := v.Repositories.GetFederated(ctx, "npm-federated")
+	assert.Nil(t, err)
+	assert.Equal(t, repo, fetched)
+
+	repository, _, err := v.Repositories.Get(ctx, "npm-federated")
+	assert.Nil(t, err)
+	if federated, ok := AsFederated(repository); assert.True(t, ok) {
+		assert.Equal(t, repo, federated)
+	}
+
+	(*repo.Members)[1].Enabled = Bool(true)
+	_, err = v.Repositories.UpdateFederated(ctx, "npm-federated", repo)
+	assert.Nil(t, err)
+	fetched, _, err = v.Repositories.GetFederated(ctx, "npm-federated")
+	assert.Nil(t, err)
+	assert.True(t, *(*fetched.Members)[1].Enabled)
+
+	_, err = v.Repositories.DeleteFederated(ctx, "npm-federated")
+	assert.Nil(t, err)
+	_, _, err = v.Repositories.GetFederated(ctx, "npm-federated")
+	assert.NotNil(t, err)
+
+	requests = nil
+	_, err = v.Repositories.ConvertLocalToFederated(ctx, "npm-local")
+	assert.Nil(t, err)
+	_, err = v.Repositories.FederationFullSync(ctx, "npm-local", nil)
+	assert.Nil(t, err)
+	_, err = v.Repositories.FederationFullSync(ctx, "npm-local", &FederationSyncOptions{Mirror: "https://site-b/artifactory/npm-local"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"POST /api/federation/migrate/npm-local",
+		"POST /api/federation/fullSync/npm-local",
+		"POST /api/federation/fullSync/npm-local?mirror=https%3A%2F%2Fsite-b%2Fartifactory%2Fnpm-local",
+	}, requests)
+}
+
+func TestDistributionRepository(t *testing.T) {
+	stored := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/api/repositories/")
+		switch r.Method {
+		case "PUT", "POST":
+			body, _ := ioutil.ReadAll(r.Body)
+			stored[key] = body
+		case "GET":
+			if body, ok := stored[key]; ok {
+				_, _ = w.Write(body)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case "DELETE":
+			delete(stored, key)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	repo := &DistributionRepository{
+		Key:                String("releases"),
+		RClass:             String(RepositoryClassDistribution),
+		PackageType:        String("generic"),
+		BintrayApplication: String("my-app"),
+		DefaultLicenses:    &[]string{"Apache-2.0"},
+		Rules: &[]DistributionRule{{
+			Name:       String("maven"),
+			Type:       String("maven"),
+			RepoFilter: String("libs-*"),
+			DistributionCoordinates: &DistributionCoordinates{
+				Repo:    String("${repo}"),
+				Pkg:     String("${groupId}:${artifactId}"),
+				Version: String("${version}"),
+				Path:    String("${path}"),
+			},
+		}},
+		GpgSign: Bool(false),
+	}
+	_, err := v.Repositories.CreateDistribution(ctx, repo)
+	assert.Nil(t, err)
+	assert.Contains(t, string(stored["releases"]), `"pkg":"${groupId}:${artifactId}"`)
+
+	fetched, _, err := v.Repositories.GetDistribution(ctx, "releases")
+	assert.Nil(t, err)
+	assert.Equal(t, repo, fetched)
+
+	repository, _, err := v.Repositories.Get(ctx, "releases")
+	assert.Nil(t, err)
+	if distribution, ok := AsDistribution(repository); assert.True(t, ok) {
+		assert.Equal(t, repo, distribution)
+	}
+
+	repo.Proxy = String("corporate")
+	_, err = v.Repositories.UpdateDistribution(ctx, "releases", repo)
+	assert.Nil(t, err)
+	fetched, _, err = v.Repositories.GetDistribution(ctx, "releases")
+	assert.Nil(t, err)
+	assert.Equal(t, "corporate", *fetched.Proxy)
+
+	_, err = v.Repositories.DeleteDistribution(ctx, "releases")
+	assert.Nil(t, err)
+	_, _, err = v.Repositories.GetDistribution(ctx, "releases")
+	assert.NotNil(t, err)
+}
+
+func TestRepositoryUnknownFields(t *testing.T) {
+	var updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			_, _ = fmt.Fprint(w, `{"key": "libs-local", "rclass": "local", "packageType": "maven", "cdnRedirect": true, "primaryKeyPairRef": "main"}`)
+		case "POST":
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Nil(t, json.Unmarshal(body, &updated))
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	repository, _, err := v.Repositories.GetLocal(ctx, "libs-local")
+	assert.Nil(t, err)
+	assert.Equal(t, client.UnknownFields{
+		"cdnRedirect":       json.RawMessage(`true`),
+		"primaryKeyPairRef": json.RawMessage(`"main"`),
+	}, repository.GetUnknownFields())
+
+	repository.Description = String("libraries")
+	_, err = v.Repositories.UpdateLocal(ctx, "libs-local", repository)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"key":               "libs-local",
+		"rclass":            "local",
+		"packageType":       "maven",
+		"description":       "libraries",
+		"cdnRedirect":       true,
+		"primaryKeyPairRef": "main",
+	}, updated)
+
+	// the unknown fields are not validated
+	assert.Nil(t, ValidateRepository(repository))
+
+	generic, _, err := v.Repositories.Get(ctx, "libs-local")
+	assert.Nil(t, err)
+	assert.Len(t, generic.GetUnknownFields(), 2)
+}
@@ -0,0 +1,712 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	PackageTypeConan   = "conan"
+	PackageTypeDebian  = "debian"
+	PackageTypeDocker  = "docker"
+	PackageTypeGeneric = "generic"
+	PackageTypeGo      = "go"
+	PackageTypeHelm    = "helm"
+	PackageTypeMaven   = "maven"
+	PackageTypeNpm     = "npm"
+	PackageTypeNuget   = "nuget"
+	PackageTypePypi    = "pypi"
+	PackageTypeRpm     = "rpm"
+)
+
+// repositoryCommonFields are the configuration attributes which apply to every package type
+var repositoryCommonFields = stringSet(
+	// all the classes
+	"key", "rclass", "packageType", "description", "notes", "includesPattern", "excludesPattern", "repoLayoutRef",
+	"blackedOut", "propertySets", "archiveBrowsingEnabled", "xrayIndex", "blockXrayUnscannedArtifacts",
+	"xrayMinimumBlockedSeverity", "downloadRedirect",
+	// local
+	"checksumPolicyType",
+	// remote
+	"url", "username", "password", "proxy", "offline", "socketTimeoutMillis", "retrievalCachePeriodSecs",
+	"missedRetrievalCachePeriodSecs", "failedRetrievalCachePeriodSecs", "assumedOfflinePeriodSecs", "hardFail",
+	"storeArtifactsLocally", "allowAnyHostAuth", "enableCookieManagement", "bypassHeadRequests",
+	"blockMismatchingMimeTypes", "mismatchingMimeTypesOverrideList", "localAddress", "shareConfiguration",
+	"synchronizeProperties", "unusedArtifactsCleanupEnabled", "unusedArtifactsCleanupPeriodHours",
+	"contentSynchronisation", "listRemoteFolderItems", "propagateQueryParams", "clientTlsCertificate",
+	// virtual
+	"repositories", "defaultDeploymentRepo", "artifactoryRequestsCanRetrieveRemoteArtifacts",
+	"virtualRetrievalCachePeriodSecs", "keyPair",
+)
+
+// repositoryClassFields are the configuration attributes which only apply to the given repository class
+var repositoryClassFields = map[string]string{
+	"members": RepositoryClassFederated,
+}
+
+// repositoryPackageFields are the configuration attributes which only apply to some package types
+var repositoryPackageFields = map[string]map[string]bool{
+	PackageTypeConan:   stringSet(),
+	PackageTypeDebian:  stringSet("debianTrivialLayout"),
+	PackageTypeDocker:  stringSet("dockerApiVersion", "maxUniqueTags", "enableTokenAuthentication"),
+	PackageTypeGeneric: stringSet(),
+	PackageTypeGo:      stringSet("vcsType", "vcsGitProvider", "vcsGitDownloadUrl", "externalDependenciesEnabled"),
+	PackageTypeHelm:    stringSet(),
+	PackageTypeMaven: stringSet("handleReleases", "handleSnapshots", "maxUniqueSnapshots", "snapshotVersionBehavior",
+		"suppressPomConsistencyChecks", "fetchJarsEagerly", "fetchSourcesEagerly", "rejectInvalidJars",
+		"remoteRepoChecksumPolicyType", "pomRepositoryReferencesCleanupPolicy"),
+	PackageTypeNpm: stringSet("externalDependenciesEnabled"),
+	PackageTypeNuget: stringSet("maxUniqueSnapshots", "forceNugetAuthentication", "nuget", "feedContextPath",
+		"downloadContextPath", "v3FeedUrl"),
+	PackageTypePypi: stringSet("pyPiRegistryUrl"),
+	PackageTypeRpm:  stringSet("calculateYumMetadata", "yumRootDepth", "enableFileListsIndexing"),
+}
+
+// repositoryAllowedValues are the values accepted by the enumerated attributes
+var repositoryAllowedValues = map[string][]string{
+	"checksumPolicyType":                   {"client-checksums", "server-generated-checksums"},
+	"dockerApiVersion":                     {"V1", "V2"},
+	"pomRepositoryReferencesCleanupPolicy": {"discard_active_reference", "discard_any_reference", "nothing"},
+	"remoteRepoChecksumPolicyType":         {"generate-if-absent", "fail", "ignore-and-generate", "pass-thru"},
+	"snapshotVersionBehavior":              {"unique", "non-unique", "deployer"},
+	"vcsGitProvider":                       {"GITHUB", "BITBUCKET", "OLDSTASH", "STASH", "ARTIFACTORY", "CUSTOM"},
+}
+
+func stringSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// ValidateRepository checks a repository configuration before it's created or updated: the mandatory attributes,
+// the values of the enumerated attributes, the attributes which don't apply to the class of the repository and, for
+// the package types with a builder, those which don't apply to its package type. The unknown fields are not checked.
+func ValidateRepository(r Repository) error {
+	v := &ValidationError{Subject: fmt.Sprintf("repository [%s]", r.GetKey())}
+	if r.GetKey() == "" {
+		v.addf("key is required")
+	}
+	if r.GetRClass() == "" {
+		v.addf("rclass is required")
+	}
+	if r.GetPackageType() == "" {
+		v.addf("packageType is required")
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if r.GetRClass() == RepositoryClassRemote {
+		if url, _ := fields["url"].(string); url == "" {
+			v.addf("url is required")
+		} else if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			v.addf("url [%s] is not an http(s) URL", url)
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	packageFields, known := repositoryPackageFields[strings.ToLower(r.GetPackageType())]
+	unknown := r.GetUnknownFields()
+	for _, name := range names {
+		if _, ok := unknown[name]; ok {
+			continue // not modelled by the client, nothing to check
+		}
+		if rclass, ok := repositoryClassFields[name]; ok {
+			if rclass != r.GetRClass() {
+				v.addf("%s doesn't apply to %s repositories", name, r.GetRClass())
+			}
+		} else if known && !repositoryCommonFields[name] && !packageFields[name] {
+			v.addf("%s doesn't apply to %s repositories", name, r.GetPackageType())
+		}
+		if allowed, ok := repositoryAllowedValues[name]; ok {
+			if value, _ := fields[name].(string); !containsString(allowed, value) {
+				v.addf("%s [%v] is not one of %s", name, fields[name], strings.Join(allowed, "|"))
+			}
+		}
+	}
+	return v.errorOrNil()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RepositoryBase holds the settings shared by the repositories of every package type, for the repository builders
+type RepositoryBase struct {
+	Key             string // Mandatory
+	Description     string
+	Notes           string
+	IncludesPattern string
+	ExcludesPattern string
+	RepoLayoutRef   string // Default: the usual layout of the package type
+	XrayIndex       bool
+	PropertySets    []string
+
+	// Remote repositories only
+	Username                       string
+	Password                       string
+	Proxy                          string
+	Offline                        bool
+	SocketTimeoutMillis            *int
+	RetrievalCachePeriodSecs       *int
+	MissedRetrievalCachePeriodSecs *int
+
+	// Virtual repositories only
+	DefaultDeploymentRepo string
+}
+
+// repositoryBuilder is implemented by the builders of each package type, which set their own attributes on the
+// configurations built from their RepositoryBase
+type repositoryBuilder interface {
+	repositoryBase() RepositoryBase
+	packageType() string
+	defaultLayout() string
+	local(r *LocalRepository)
+	remote(r *RemoteRepository)
+	virtual(r *VirtualRepository)
+}
+
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return String(v)
+}
+
+func optionalBool(v bool) *bool {
+	if !v {
+		return nil
+	}
+	return Bool(v)
+}
+
+func optionalInt(v int) *int {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+func optionalStrings(v []string) *[]string {
+	if len(v) == 0 {
+		return nil
+	}
+	return &v
+}
+
+func repositoryLayout(b repositoryBuilder) *string {
+	if layout := b.repositoryBase().RepoLayoutRef; layout != "" {
+		return String(layout)
+	}
+	return String(b.defaultLayout())
+}
+
+func buildLocal(b repositoryBuilder) (*LocalRepository, error) {
+	base := b.repositoryBase()
+	r := &LocalRepository{
+		Key:             String(base.Key),
+		RClass:          String(RepositoryClassLocal),
+		PackageType:     String(b.packageType()),
+		Description:     optionalString(base.Description),
+		Notes:           optionalString(base.Notes),
+		IncludesPattern: optionalString(base.IncludesPattern),
+		ExcludesPattern: optionalString(base.ExcludesPattern),
+		RepoLayoutRef:   repositoryLayout(b),
+		XrayIndex:       optionalBool(base.XrayIndex),
+		PropertySets:    optionalStrings(base.PropertySets),
+	}
+	b.local(r)
+	if err := ValidateRepository(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func buildRemote(b repositoryBuilder, url string) (*RemoteRepository, error) {
+	base := b.repositoryBase()
+	r := &RemoteRepository{
+		Key:                            String(base.Key),
+		RClass:                         String(RepositoryClassRemote),
+		PackageType:                    String(b.packageType()),
+		Url:                            String(url),
+		Description:                    optionalString(base.Description),
+		Notes:                          optionalString(base.Notes),
+		IncludesPattern:                optionalString(base.IncludesPattern),
+		ExcludesPattern:                optionalString(base.ExcludesPattern),
+		RepoLayoutRef:                  repositoryLayout(b),
+		XrayIndex:                      optionalBool(base.XrayIndex),
+		PropertySets:                   optionalStrings(base.PropertySets),
+		Username:                       optionalString(base.Username),
+		Password:                       optionalString(base.Password),
+		Proxy:                          optionalString(base.Proxy),
+		Offline:                        optionalBool(base.Offline),
+		SocketTimeoutMillis:            base.SocketTimeoutMillis,
+		RetrievalCachePeriodSecs:       base.RetrievalCachePeriodSecs,
+		MissedRetrievalCachePeriodSecs: base.MissedRetrievalCachePeriodSecs,
+	}
+	b.remote(r)
+	if err := ValidateRepository(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func buildVirtual(b repositoryBuilder, repositories []string) (*VirtualRepository, error) {
+	base := b.repositoryBase()
+	r := &VirtualRepository{
+		Key:                   String(base.Key),
+		RClass:                String(RepositoryClassVirtual),
+		PackageType:           String(b.packageType()),
+		Description:           optionalString(base.Description),
+		Notes:                 optionalString(base.Notes),
+		IncludesPattern:       optionalString(base.IncludesPattern),
+		ExcludesPattern:       optionalString(base.ExcludesPattern),
+		RepoLayoutRef:         repositoryLayout(b),
+		DefaultDeploymentRepo: optionalString(base.DefaultDeploymentRepo),
+		Repositories:          &repositories,
+	}
+	if repositories == nil {
+		r.Repositories = &[]string{}
+	}
+	b.virtual(r)
+	if err := ValidateRepository(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// MavenRepositoryBuilder builds the configurations of maven repositories
+type MavenRepositoryBuilder struct {
+	RepositoryBase
+	HandleReleases               *bool  // Default: true
+	HandleSnapshots              *bool  // Default: true
+	MaxUniqueSnapshots           int    // Default: 0, unlimited
+	SnapshotVersionBehavior      string // One of unique|non-unique|deployer. Default: unique
+	ChecksumPolicyType           string // One of client-checksums|server-generated-checksums. Default: client-checksums
+	SuppressPomConsistencyChecks bool
+
+	// Remote repositories only
+	FetchJarsEagerly             bool
+	FetchSourcesEagerly          bool
+	RejectInvalidJars            bool
+	RemoteRepoChecksumPolicyType string // One of generate-if-absent|fail|ignore-and-generate|pass-thru. Default: generate-if-absent
+
+	// Virtual repositories only
+	PomRepositoryReferencesCleanupPolicy string // One of discard_active_reference|discard_any_reference|nothing. Default: discard_active_reference
+}
+
+func (b MavenRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b MavenRepositoryBuilder) packageType() string            { return PackageTypeMaven }
+func (b MavenRepositoryBuilder) defaultLayout() string          { return "maven-2-default" }
+
+func (b MavenRepositoryBuilder) handling() (*bool, *bool) {
+	releases, snapshots := b.HandleReleases, b.HandleSnapshots
+	if releases == nil {
+		releases = Bool(true)
+	}
+	if snapshots == nil {
+		snapshots = Bool(true)
+	}
+	return releases, snapshots
+}
+
+func (b MavenRepositoryBuilder) local(r *LocalRepository) {
+	r.HandleReleases, r.HandleSnapshots = b.handling()
+	r.MaxUniqueSnapshots = optionalInt(b.MaxUniqueSnapshots)
+	r.SnapshotVersionBehavior = String(defaultString(b.SnapshotVersionBehavior, "unique"))
+	r.ChecksumPolicyType = String(defaultString(b.ChecksumPolicyType, "client-checksums"))
+	r.SuppressPomConsistencyChecks = Bool(b.SuppressPomConsistencyChecks)
+}
+
+func (b MavenRepositoryBuilder) remote(r *RemoteRepository) {
+	r.HandleReleases, r.HandleSnapshots = b.handling()
+	r.MaxUniqueSnapshots = optionalInt(b.MaxUniqueSnapshots)
+	r.SuppressPomConsistencyChecks = Bool(b.SuppressPomConsistencyChecks)
+	r.FetchJarsEagerly = optionalBool(b.FetchJarsEagerly)
+	r.FetchSourcesEagerly = optionalBool(b.FetchSourcesEagerly)
+	r.RejectInvalidJars = optionalBool(b.RejectInvalidJars)
+	r.RemoteRepoChecksumPolicyType = String(defaultString(b.RemoteRepoChecksumPolicyType, "generate-if-absent"))
+}
+
+func (b MavenRepositoryBuilder) virtual(r *VirtualRepository) {
+	r.PomRepositoryReferencesCleanupPolicy = String(defaultString(b.PomRepositoryReferencesCleanupPolicy, "discard_active_reference"))
+}
+
+// Local returns the configuration of a local maven repository
+func (b MavenRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote maven repository proxying the given URL
+func (b MavenRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual maven repository aggregating the given repositories
+func (b MavenRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// DockerRepositoryBuilder builds the configurations of docker repositories
+type DockerRepositoryBuilder struct {
+	RepositoryBase
+	ApiVersion    string // One of V1|V2. Default: V2
+	MaxUniqueTags int    // Default: 0, unlimited. Local and remote repositories only
+
+	// Remote repositories only
+	EnableTokenAuthentication *bool // Default: true
+}
+
+func (b DockerRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b DockerRepositoryBuilder) packageType() string            { return PackageTypeDocker }
+func (b DockerRepositoryBuilder) defaultLayout() string          { return "simple-default" }
+
+func (b DockerRepositoryBuilder) local(r *LocalRepository) {
+	r.DockerApiVersion = String(defaultString(b.ApiVersion, "V2"))
+	r.MaxUniqueTags = optionalInt(b.MaxUniqueTags)
+}
+
+func (b DockerRepositoryBuilder) remote(r *RemoteRepository) {
+	r.DockerApiVersion = String(defaultString(b.ApiVersion, "V2"))
+	r.MaxUniqueTags = optionalInt(b.MaxUniqueTags)
+	r.EnableTokenAuthentication = b.EnableTokenAuthentication
+	if r.EnableTokenAuthentication == nil {
+		r.EnableTokenAuthentication = Bool(true)
+	}
+}
+
+func (b DockerRepositoryBuilder) virtual(r *VirtualRepository) {
+	r.DockerApiVersion = String(defaultString(b.ApiVersion, "V2"))
+}
+
+// Local returns the configuration of a local docker repository
+func (b DockerRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote docker repository proxying the given registry
+func (b DockerRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual docker repository aggregating the given repositories
+func (b DockerRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// NpmRepositoryBuilder builds the configurations of npm repositories
+type NpmRepositoryBuilder struct {
+	RepositoryBase
+
+	// Virtual repositories only
+	ExternalDependenciesEnabled bool
+}
+
+func (b NpmRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b NpmRepositoryBuilder) packageType() string            { return PackageTypeNpm }
+func (b NpmRepositoryBuilder) defaultLayout() string          { return "npm-default" }
+func (b NpmRepositoryBuilder) local(r *LocalRepository)       {}
+func (b NpmRepositoryBuilder) remote(r *RemoteRepository)     {}
+
+func (b NpmRepositoryBuilder) virtual(r *VirtualRepository) {
+	r.ExternalDependenciesEnabled = optionalBool(b.ExternalDependenciesEnabled)
+}
+
+// Local returns the configuration of a local npm repository
+func (b NpmRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote npm repository proxying the given registry
+func (b NpmRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual npm repository aggregating the given repositories
+func (b NpmRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// PypiRepositoryBuilder builds the configurations of PyPI repositories
+type PypiRepositoryBuilder struct {
+	RepositoryBase
+
+	// Remote repositories only
+	RegistryUrl string // Default: https://pypi.org
+}
+
+func (b PypiRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b PypiRepositoryBuilder) packageType() string            { return PackageTypePypi }
+func (b PypiRepositoryBuilder) defaultLayout() string          { return "simple-default" }
+func (b PypiRepositoryBuilder) local(r *LocalRepository)       {}
+func (b PypiRepositoryBuilder) virtual(r *VirtualRepository)   {}
+
+func (b PypiRepositoryBuilder) remote(r *RemoteRepository) {
+	r.PyPiRegistryUrl = String(defaultString(b.RegistryUrl, "https://pypi.org"))
+}
+
+// Local returns the configuration of a local PyPI repository
+func (b PypiRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote PyPI repository proxying the given index
+func (b PypiRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual PyPI repository aggregating the given repositories
+func (b PypiRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// HelmRepositoryBuilder builds the configurations of helm repositories
+type HelmRepositoryBuilder struct {
+	RepositoryBase
+}
+
+func (b HelmRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b HelmRepositoryBuilder) packageType() string            { return PackageTypeHelm }
+func (b HelmRepositoryBuilder) defaultLayout() string          { return "simple-default" }
+func (b HelmRepositoryBuilder) local(r *LocalRepository)       {}
+func (b HelmRepositoryBuilder) remote(r *RemoteRepository)     {}
+func (b HelmRepositoryBuilder) virtual(r *VirtualRepository)   {}
+
+// Local returns the configuration of a local helm repository
+func (b HelmRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote helm repository proxying the given chart repository
+func (b HelmRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual helm repository aggregating the given repositories
+func (b HelmRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// NugetRepositoryBuilder builds the configurations of NuGet repositories
+type NugetRepositoryBuilder struct {
+	RepositoryBase
+	MaxUniqueSnapshots  int // Local and remote repositories only
+	ForceAuthentication bool
+
+	// Remote repositories only
+	FeedContextPath     string // Default: api/v2
+	DownloadContextPath string // Default: api/v2/package
+	V3FeedUrl           string
+}
+
+func (b NugetRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b NugetRepositoryBuilder) packageType() string            { return PackageTypeNuget }
+func (b NugetRepositoryBuilder) defaultLayout() string          { return "nuget-default" }
+
+func (b NugetRepositoryBuilder) local(r *LocalRepository) {
+	r.MaxUniqueSnapshots = optionalInt(b.MaxUniqueSnapshots)
+	r.ForceNugetAuthentication = optionalBool(b.ForceAuthentication)
+}
+
+func (b NugetRepositoryBuilder) remote(r *RemoteRepository) {
+	r.MaxUniqueSnapshots = optionalInt(b.MaxUniqueSnapshots)
+	r.ForceNugetAuthentication = optionalBool(b.ForceAuthentication)
+	r.FeedContextPath = String(defaultString(b.FeedContextPath, "api/v2"))
+	r.DownloadContextPath = String(defaultString(b.DownloadContextPath, "api/v2/package"))
+	r.V3FeedUrl = optionalString(b.V3FeedUrl)
+}
+
+func (b NugetRepositoryBuilder) virtual(r *VirtualRepository) {
+	r.ForceNugetAuthentication = optionalBool(b.ForceAuthentication)
+}
+
+// Local returns the configuration of a local NuGet repository
+func (b NugetRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote NuGet repository proxying the given feed
+func (b NugetRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual NuGet repository aggregating the given repositories
+func (b NugetRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// GoRepositoryBuilder builds the configurations of go repositories
+type GoRepositoryBuilder struct {
+	RepositoryBase
+
+	// Remote repositories only
+	VcsGitProvider    string // One of GITHUB|BITBUCKET|OLDSTASH|STASH|ARTIFACTORY|CUSTOM. Default: ARTIFACTORY
+	VcsGitDownloadUrl string // Mandatory for the CUSTOM provider
+
+	// Virtual repositories only
+	ExternalDependenciesEnabled bool
+}
+
+func (b GoRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b GoRepositoryBuilder) packageType() string            { return PackageTypeGo }
+func (b GoRepositoryBuilder) defaultLayout() string          { return "go-default" }
+func (b GoRepositoryBuilder) local(r *LocalRepository)       {}
+
+func (b GoRepositoryBuilder) remote(r *RemoteRepository) {
+	r.VcsType = String("GIT")
+	r.VcsGitProvider = String(defaultString(b.VcsGitProvider, "ARTIFACTORY"))
+	r.VcsGitDownloadUrl = optionalString(b.VcsGitDownloadUrl)
+}
+
+func (b GoRepositoryBuilder) virtual(r *VirtualRepository) {
+	r.ExternalDependenciesEnabled = optionalBool(b.ExternalDependenciesEnabled)
+}
+
+// Local returns the configuration of a local go repository
+func (b GoRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote go repository proxying the given URL
+func (b GoRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	if b.VcsGitProvider == "CUSTOM" && b.VcsGitDownloadUrl == "" {
+		return nil, &ValidationError{Subject: fmt.Sprintf("repository [%s]", b.Key), Problems: []string{"vcsGitDownloadUrl is required for the CUSTOM provider"}}
+	}
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual go repository aggregating the given repositories
+func (b GoRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// DebianRepositoryBuilder builds the configurations of debian repositories
+type DebianRepositoryBuilder struct {
+	RepositoryBase
+	TrivialLayout bool
+}
+
+func (b DebianRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b DebianRepositoryBuilder) packageType() string            { return PackageTypeDebian }
+func (b DebianRepositoryBuilder) defaultLayout() string          { return "simple-default" }
+
+func (b DebianRepositoryBuilder) local(r *LocalRepository) {
+	r.DebianTrivialLayout = Bool(b.TrivialLayout)
+}
+
+func (b DebianRepositoryBuilder) remote(r *RemoteRepository) {
+	r.DebianTrivialLayout = Bool(b.TrivialLayout)
+}
+
+func (b DebianRepositoryBuilder) virtual(r *VirtualRepository) {
+	r.DebianTrivialLayout = Bool(b.TrivialLayout)
+}
+
+// Local returns the configuration of a local debian repository
+func (b DebianRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote debian repository proxying the given URL
+func (b DebianRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual debian repository aggregating the given repositories
+func (b DebianRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// RpmRepositoryBuilder builds the configurations of RPM (YUM) repositories
+type RpmRepositoryBuilder struct {
+	RepositoryBase
+
+	// Local repositories only
+	CalculateYumMetadata    bool
+	YumRootDepth            int
+	EnableFileListsIndexing bool
+}
+
+func (b RpmRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b RpmRepositoryBuilder) packageType() string            { return PackageTypeRpm }
+func (b RpmRepositoryBuilder) defaultLayout() string          { return "simple-default" }
+func (b RpmRepositoryBuilder) remote(r *RemoteRepository)     {}
+func (b RpmRepositoryBuilder) virtual(r *VirtualRepository)   {}
+
+func (b RpmRepositoryBuilder) local(r *LocalRepository) {
+	r.CalculateYumMetadata = Bool(b.CalculateYumMetadata)
+	r.YumRootDepth = optionalInt(b.YumRootDepth)
+	r.EnableFileListsIndexing = optionalBool(b.EnableFileListsIndexing)
+}
+
+// Local returns the configuration of a local RPM repository
+func (b RpmRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote RPM repository proxying the given URL
+func (b RpmRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual RPM repository aggregating the given repositories
+func (b RpmRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// GenericRepositoryBuilder builds the configurations of generic repositories
+type GenericRepositoryBuilder struct {
+	RepositoryBase
+}
+
+func (b GenericRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b GenericRepositoryBuilder) packageType() string            { return PackageTypeGeneric }
+func (b GenericRepositoryBuilder) defaultLayout() string          { return "simple-default" }
+func (b GenericRepositoryBuilder) local(r *LocalRepository)       {}
+func (b GenericRepositoryBuilder) remote(r *RemoteRepository)     {}
+func (b GenericRepositoryBuilder) virtual(r *VirtualRepository)   {}
+
+// Local returns the configuration of a local generic repository
+func (b GenericRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote generic repository proxying the given URL
+func (b GenericRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual generic repository aggregating the given repositories
+func (b GenericRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+// ConanRepositoryBuilder builds the configurations of conan repositories
+type ConanRepositoryBuilder struct {
+	RepositoryBase
+}
+
+func (b ConanRepositoryBuilder) repositoryBase() RepositoryBase { return b.RepositoryBase }
+func (b ConanRepositoryBuilder) packageType() string            { return PackageTypeConan }
+func (b ConanRepositoryBuilder) defaultLayout() string          { return "conan-default" }
+func (b ConanRepositoryBuilder) local(r *LocalRepository)       {}
+func (b ConanRepositoryBuilder) remote(r *RemoteRepository)     {}
+func (b ConanRepositoryBuilder) virtual(r *VirtualRepository)   {}
+
+// Local returns the configuration of a local conan repository
+func (b ConanRepositoryBuilder) Local() (*LocalRepository, error) { return buildLocal(b) }
+
+// Remote returns the configuration of a remote conan repository proxying the given URL
+func (b ConanRepositoryBuilder) Remote(url string) (*RemoteRepository, error) {
+	return buildRemote(b, url)
+}
+
+// Virtual returns the configuration of a virtual conan repository aggregating the given repositories
+func (b ConanRepositoryBuilder) Virtual(repositories ...string) (*VirtualRepository, error) {
+	return buildVirtual(b, repositories)
+}
+
+func defaultString(v string, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryBuilders(t *testing.T) {
+	local, err := MavenRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "libs-local", Description: "libs"}}.Local()
+	assert.Nil(t, err)
+	assert.Equal(t, RepositoryClassLocal, *local.RClass)
+	assert.Equal(t, PackageTypeMaven, *local.PackageType)
+	assert.Equal(t, "maven-2-default", *local.RepoLayoutRef)
+	assert.True(t, *local.HandleReleases)
+	assert.True(t, *local.HandleSnapshots)
+	assert.Equal(t, "unique", *local.SnapshotVersionBehavior)
+	assert.Equal(t, "client-checksums", *local.ChecksumPolicyType)
+	assert.Nil(t, local.DockerApiVersion)
+
+	remote, err := DockerRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "docker-remote"}}.Remote("https://registry-1.docker.io")
+	assert.Nil(t, err)
+	assert.Equal(t, "V2", *remote.DockerApiVersion)
+	assert.True(t, *remote.EnableTokenAuthentication)
+	assert.Equal(t, "simple-default", *remote.RepoLayoutRef)
+	assert.Nil(t, remote.FeedContextPath)
+
+	remote, err = GoRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "go-remote"}}.Remote("https://proxy.golang.org")
+	assert.Nil(t, err)
+	assert.Equal(t, "GIT", *remote.VcsType)
+	assert.Equal(t, "ARTIFACTORY", *remote.VcsGitProvider)
+	_, err = GoRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "go-remote"}, VcsGitProvider: "CUSTOM"}.Remote("https://proxy.golang.org")
+	assert.NotNil(t, err)
+
+	remote, err = NugetRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "nuget-remote"}}.Remote("https://www.nuget.org")
+	assert.Nil(t, err)
+	assert.Equal(t, "api/v2", *remote.FeedContextPath)
+	assert.Equal(t, "api/v2/package", *remote.DownloadContextPath)
+	assert.Equal(t, "nuget-default", *remote.RepoLayoutRef)
+
+	virtual, err := NpmRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "npm", DefaultDeploymentRepo: "npm-local"}}.Virtual("npm-local", "npm-remote")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"npm-local", "npm-remote"}, *virtual.Repositories)
+	assert.Equal(t, "npm-local", *virtual.DefaultDeploymentRepo)
+	assert.Equal(t, "npm-default", *virtual.RepoLayoutRef)
+
+	virtual, err = GenericRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "generic"}}.Virtual()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, *virtual.Repositories)
+
+	_, err = RpmRepositoryBuilder{}.Local()
+	assert.NotNil(t, err)
+	_, err = PypiRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "pypi-remote"}}.Remote("pypi.org")
+	assert.NotNil(t, err)
+	_, err = DockerRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "docker-local"}, ApiVersion: "V3"}.Local()
+	assert.NotNil(t, err)
+}
+
+func TestValidateRepository(t *testing.T) {
+	repo := &LocalRepository{
+		Key:              String("npm-local"),
+		RClass:           String(RepositoryClassLocal),
+		PackageType:      String(PackageTypeNpm),
+		DockerApiVersion: String("V2"),
+		HandleSnapshots:  Bool(true),
+	}
+	err := ValidateRepository(repo)
+	if assert.IsType(t, &ValidationError{}, err) {
+		assert.Equal(t, []string{
+			"dockerApiVersion doesn't apply to npm repositories",
+			"handleSnapshots doesn't apply to npm repositories",
+		}, err.(*ValidationError).Problems)
+	}
+
+	// the attributes of the package types without a builder are not checked
+	repo.PackageType = String("bower")
+	assert.Nil(t, ValidateRepository(repo))
+
+	err = ValidateRepository(&RemoteRepository{Key: String("remote"), RClass: String(RepositoryClassRemote)})
+	if assert.IsType(t, &ValidationError{}, err) {
+		assert.Equal(t, []string{"packageType is required", "url is required"}, err.(*ValidationError).Problems)
+	}
+
+	federated := &FederatedRepository{
+		Key:         String("npm-federated"),
+		RClass:      String(RepositoryClassFederated),
+		PackageType: String(PackageTypeNpm),
+		Members:     &[]FederatedMember{{Url: String("https://other/artifactory/npm-federated")}},
+	}
+	assert.Nil(t, ValidateRepository(federated))
+	federated.RClass = String(RepositoryClassLocal)
+	assert.EqualError(t, ValidateRepository(federated), "invalid repository [npm-federated]: members doesn't apply to local repositories")
+}
+
+func TestCreateBuiltRepository(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/repositories/helm-local", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Nil(t, json.Unmarshal(body, &created))
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	repo, err := HelmRepositoryBuilder{RepositoryBase: RepositoryBase{Key: "helm-local", XrayIndex: true}}.Local()
+	assert.Nil(t, err)
+	_, err = v.Repositories.CreateLocal(context.Background(), repo)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"key":           "helm-local",
+		"rclass":        "local",
+		"packageType":   "helm",
+		"repoLayoutRef": "simple-default",
+		"xrayIndex":     true,
+	}, created)
+}
@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// writeOnlyRepositoryFields can't be compared, as Artifactory doesn't return them as they were set
+var writeOnlyRepositoryFields = map[string]bool{"password": true}
+
+// RepositoryFieldChange is an attribute of a repository whose current value differs from the desired one. The values
+// are decoded from the JSON configuration, nil when the attribute is not set.
+type RepositoryFieldChange struct {
+	Field     string
+	Current   interface{}
+	Desired   interface{}
+	WriteOnly bool // The attribute can't be compared, its current value is unknown
+}
+
+// DiffRepository compares the attributes set on the desired configuration of a repository, including its unknown
+// fields, with the current ones and returns the differences sorted by attribute. The attributes not set on the
+// desired configuration are not compared. The write only attributes set on it, like the password, can't be compared
+// and are always reported as changed.
+func DiffRepository(current Repository, desired Repository) ([]RepositoryFieldChange, error) {
+	currentFields, err := repositoryFields(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredFields, err := repositoryFields(desired)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(desiredFields))
+	for name := range desiredFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changes := make([]RepositoryFieldChange, 0)
+	for _, name := range names {
+		if writeOnlyRepositoryFields[name] {
+			changes = append(changes, RepositoryFieldChange{Field: name, Desired: desiredFields[name], WriteOnly: true})
+			continue
+		}
+		if reflect.DeepEqual(currentFields[name], desiredFields[name]) {
+			continue
+		}
+		changes = append(changes, RepositoryFieldChange{Field: name, Current: currentFields[name], Desired: desiredFields[name]})
+	}
+	return changes, nil
+}
+
+func repositoryFields(r Repository) (map[string]interface{}, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// RepositoryRevision returns a fingerprint of the configuration of a repository, which changes whenever one of its
+// attributes, unknown fields included, changes
+func RepositoryRevision(r Repository) (string, error) {
+	fields, err := repositoryFields(r)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(fields) // the keys of the maps are sorted
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RepositoryConflictError is returned by Patch when the configuration of the repository isn't the expected one
+type RepositoryConflictError struct {
+	Key      string
+	Expected string // Revision expected
+	Actual   string // Current revision
+}
+
+func (e *RepositoryConflictError) Error() string {
+	return fmt.Sprintf("repository [%s] was changed concurrently: revision [%s] expected, found [%s]", e.Key, e.Expected, e.Actual)
+}
+
+type RepositoryPatchOptions struct {
+	// Revision, as returned by RepositoryRevision, of the configuration the desired one is based on. The update fails
+	// with a RepositoryConflictError if the current configuration has another revision. Default: not checked
+	ExpectedRevision string
+	DryRun           bool // Computes the changes without updating the repository
+}
+
+type RepositoryPatchResult struct {
+	Key      string
+	Changes  []RepositoryFieldChange // Changed attributes, none when the repository is up to date
+	Revision string                  // Revision of the configuration after the update
+}
+
+// Patch updates a repository of any class with the attributes of the desired configuration which differ from the
+// current ones, and only them, and reports the changed attributes. Nothing is sent when the repository is up to date.
+// The write only attributes set on the desired configuration, like the password, can't be compared: they are always
+// sent, so such a repository is never up to date. The current configuration is fetched
+// again right before the update: if it changed since the changes were computed, or isn't of the expected revision,
+// the update fails with a RepositoryConflictError. Artifactory has no conditional update of the repositories, so a
+// change made between this last check and the update is still overwritten. The rclass can't be changed.
+// Security: Requires an admin user
+func (s *RepositoriesService) Patch(ctx context.Context, desired Repository, opt *RepositoryPatchOptions) (*RepositoryPatchResult, *http.Response, error) {
+	if opt == nil {
+		opt = new(RepositoryPatchOptions)
+	}
+	key := desired.GetKey()
+	current, resp, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, resp, err
+	}
+	if desired.GetRClass() != "" && desired.GetRClass() != current.GetRClass() {
+		return nil, resp, fmt.Errorf("repository [%s] is %s, it can't become %s", key, current.GetRClass(), desired.GetRClass())
+	}
+	revision, err := RepositoryRevision(current)
+	if err != nil {
+		return nil, resp, err
+	}
+	if opt.ExpectedRevision != "" && opt.ExpectedRevision != revision {
+		return nil, resp, &RepositoryConflictError{Key: key, Expected: opt.ExpectedRevision, Actual: revision}
+	}
+
+	changes, err := DiffRepository(current, desired)
+	if err != nil {
+		return nil, resp, err
+	}
+	result := &RepositoryPatchResult{Key: key, Changes: changes, Revision: revision}
+	if len(changes) == 0 || opt.DryRun {
+		return result, resp, nil
+	}
+
+	if current, resp, err = s.Get(ctx, key); err != nil {
+		return nil, resp, err
+	}
+	if actual, err := RepositoryRevision(current); err != nil {
+		return nil, resp, err
+	} else if actual != revision {
+		return nil, resp, &RepositoryConflictError{Key: key, Expected: revision, Actual: actual}
+	}
+
+	patch := make(map[string]interface{}, len(changes))
+	for _, c := range changes {
+		patch[c.Field] = c.Desired
+	}
+	if resp, err = s.update(ctx, key, patch); err != nil {
+		return nil, resp, err
+	}
+
+	updated, resp, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, resp, err
+	}
+	if result.Revision, err = RepositoryRevision(updated); err != nil {
+		return nil, resp, err
+	}
+	return result, resp, nil
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchRepository(t *testing.T) {
+	var mu sync.Mutex
+	config := map[string]interface{}{
+		"key": "maven-remote", "rclass": "remote", "packageType": "maven", "url": "https://repo1.maven.org/maven2",
+		"description": "maven central", "offline": false, "cdnRedirect": true,
+	}
+	gets := 0
+	onGet := func(int) {}
+	patches := make([]map[string]interface{}, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "/api/repositories/maven-remote", r.URL.Path)
+		switch r.Method {
+		case "GET":
+			gets++
+			onGet(gets)
+			_ = json.NewEncoder(w).Encode(config)
+		case "POST":
+			body, _ := ioutil.ReadAll(r.Body)
+			patch := make(map[string]interface{})
+			assert.Nil(t, json.Unmarshal(body, &patch))
+			patches = append(patches, patch)
+			for k, v := range patch {
+				config[k] = v
+			}
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	current, _, err := v.Repositories.Get(ctx, "maven-remote")
+	assert.Nil(t, err)
+	revision, err := RepositoryRevision(current)
+	assert.Nil(t, err)
+
+	desired := &RemoteRepository{
+		Key:         String("maven-remote"),
+		RClass:      String(RepositoryClassRemote),
+		Url:         String("https://repo1.maven.org/maven2"),
+		Description: String("central"),
+		Offline:     Bool(true),
+		Password:    String("secret"),
+	}
+	result, _, err := v.Repositories.Patch(ctx, desired, &RepositoryPatchOptions{DryRun: true})
+	assert.Nil(t, err)
+	assert.Len(t, result.Changes, 3)
+	assert.Empty(t, patches)
+
+	gets = 0
+	result, _, err = v.Repositories.Patch(ctx, desired, &RepositoryPatchOptions{ExpectedRevision: revision})
+	assert.Nil(t, err)
+	assert.Equal(t, []RepositoryFieldChange{
+		{Field: "description", Current: "maven central", Desired: "central"},
+		{Field: "offline", Current: false, Desired: true},
+		{Field: "password", Desired: "secret", WriteOnly: true},
+	}, result.Changes)
+	assert.Equal(t, []map[string]interface{}{{"description": "central", "offline": true, "password": "secret"}}, patches)
+	assert.Equal(t, 3, gets)
+	assert.NotEqual(t, revision, result.Revision)
+	assert.Equal(t, true, config["cdnRedirect"])
+
+	// up to date, once the password which can't be compared is left out
+	desired.Password = nil
+	result, _, err = v.Repositories.Patch(ctx, desired, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Changes)
+	assert.Len(t, patches, 1)
+
+	// based on an outdated revision
+	desired.Description = String("maven")
+	_, _, err = v.Repositories.Patch(ctx, desired, &RepositoryPatchOptions{ExpectedRevision: revision})
+	if assert.IsType(t, &RepositoryConflictError{}, err) {
+		assert.Equal(t, revision, err.(*RepositoryConflictError).Expected)
+	}
+
+	// changed between the diff and the update
+	gets = 0
+	onGet = func(n int) {
+		if n == 2 {
+			config["notes"] = "changed"
+		}
+	}
+	_, _, err = v.Repositories.Patch(ctx, desired, nil)
+	assert.IsType(t, &RepositoryConflictError{}, err)
+	assert.Len(t, patches, 1)
+
+	_, _, err = v.Repositories.Patch(ctx, &LocalRepository{Key: String("maven-remote"), RClass: String(RepositoryClassLocal)}, nil)
+	assert.EqualError(t, err, "repository [maven-remote] is remote, it can't become local")
+}
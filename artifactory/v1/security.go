@@ -54,6 +54,8 @@ type User struct {
 	LastLoggedIn             *string   `json:"lastLoggedIn,omitempty"`             // Read-only element
 	Realm                    *string   `json:"realm,omitempty"`                    // Read-only element
 	Groups                   *[]string `json:"groups,omitempty"`                   // Optional element in create/replace queries
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
 }
 
 func (r User) String() string {
@@ -61,6 +63,22 @@ func (r User) String() string {
 	return string(res)
 }
 
+// GetUnknownFields returns the attributes returned by Artifactory which User doesn't model, sent back
+// unchanged on update.
+func (r User) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r User) MarshalJSON() ([]byte, error) {
+	type user User
+	return client.MarshalWithUnknownFields(user(r), r.unknownFields)
+}
+
+func (r *User) UnmarshalJSON(data []byte) error {
+	type user User
+	unknown, err := client.UnmarshalKnownFields(data, (*user)(r))
+	r.unknownFields = unknown
+	return err
+}
+
 // Get the details of an Artifactory user
 // Since: 2.4.0
 // Notes: Requires Artifactory Pro
@@ -528,6 +546,8 @@ type Group struct {
 	AdminPrivileges *bool   `json:"adminPrivileges,omitempty"` // Optional element in create/replace queries; default: false
 	Realm           *string `json:"realm,omitempty"`           // Optional element in create/replace queries
 	RealmAttributes *string `json:"realmAttributes,omitempty"` // Optional element in create/replace queries
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
 }
 
 func (r Group) String() string {
@@ -535,6 +555,22 @@ func (r Group) String() string {
 	return string(res)
 }
 
+// GetUnknownFields returns the attributes returned by Artifactory which Group doesn't model, sent back
+// unchanged on update.
+func (r Group) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r Group) MarshalJSON() ([]byte, error) {
+	type group Group
+	return client.MarshalWithUnknownFields(group(r), r.unknownFields)
+}
+
+func (r *Group) UnmarshalJSON(data []byte) error {
+	type group Group
+	unknown, err := client.UnmarshalKnownFields(data, (*group)(r))
+	r.unknownFields = unknown
+	return err
+}
+
 // Get the details of an Artifactory Group
 // Since: 2.4.0
 // Notes: Requires Artifactory Pro
@@ -640,6 +676,8 @@ type PermissionTargets struct {
 	ExcludesPattern *string     `json:"excludesPattern,omitempty"` // Optional element in create/replace queries
 	Repositories    *[]string   `json:"repositories,omitempty"`    // Mandatory element in create/replace queries, optional in "update" queries
 	Principals      *Principals `json:"principals,omitempty"`      // Optional element in create/replace queries
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
 }
 
 func (r PermissionTargets) String() string {
@@ -647,6 +685,22 @@ func (r PermissionTargets) String() string {
 	return string(res)
 }
 
+// GetUnknownFields returns the attributes returned by Artifactory which PermissionTargets doesn't model, sent back
+// unchanged on update.
+func (r PermissionTargets) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r PermissionTargets) MarshalJSON() ([]byte, error) {
+	type permissionTargets PermissionTargets
+	return client.MarshalWithUnknownFields(permissionTargets(r), r.unknownFields)
+}
+
+func (r *PermissionTargets) UnmarshalJSON(data []byte) error {
+	type permissionTargets PermissionTargets
+	unknown, err := client.UnmarshalKnownFields(data, (*permissionTargets)(r))
+	r.unknownFields = unknown
+	return err
+}
+
 // Get the details of an Artifactory Permission Target
 // Since: 2.4.0
 // Notes: Requires Artifactory Pro
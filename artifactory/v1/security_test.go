@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityUnknownFields(t *testing.T) {
+	sent := make(map[string]map[string]interface{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/security/users/"):
+			_, _ = fmt.Fprint(w, `{"name": "jdoe", "email": "jdoe@example.com", "watchManager": true}`)
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/security/groups/"):
+			_, _ = fmt.Fprint(w, `{"name": "readers", "externalId": "abc"}`)
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/security/permissions/"):
+			_, _ = fmt.Fprint(w, `{"name": "read", "repositories": ["ANY"], "distribute": {"users": {}}}`)
+		default:
+			body, _ := ioutil.ReadAll(r.Body)
+			m := make(map[string]interface{})
+			assert.Nil(t, json.Unmarshal(body, &m))
+			sent[r.URL.Path] = m
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+	ctx := context.Background()
+
+	user, _, err := v.Security.GetUser(ctx, "jdoe")
+	assert.Nil(t, err)
+	assert.Equal(t, client.UnknownFields{"watchManager": json.RawMessage(`true`)}, user.GetUnknownFields())
+	_, err = v.Security.UpdateUser(ctx, "jdoe", user)
+	assert.Nil(t, err)
+	assert.Equal(t, true, sent["/api/security/users/jdoe"]["watchManager"])
+
+	group, _, err := v.Security.GetGroup(ctx, "readers")
+	assert.Nil(t, err)
+	_, err = v.Security.UpdateGroup(ctx, "readers", group)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", sent["/api/security/groups/readers"]["externalId"])
+
+	permission, _, err := v.Security.GetPermissionTargets(ctx, "read")
+	assert.Nil(t, err)
+	_, err = v.Security.CreateOrReplacePermissionTargets(ctx, "read", permission)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"users": map[string]interface{}{}}, sent["/api/security/permissions/read"]["distribute"])
+	assert.Contains(t, permission.String(), `"distribute"`)
+}
@@ -0,0 +1,237 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type ItemStatistics struct {
+	Uri                  *string `json:"uri,omitempty"`
+	DownloadCount        *int64  `json:"downloadCount,omitempty"`
+	LastDownloaded       *int64  `json:"lastDownloaded,omitempty"` // Milliseconds since epoch
+	LastDownloadedBy     *string `json:"lastDownloadedBy,omitempty"`
+	RemoteDownloadCount  *int64  `json:"remoteDownloadCount,omitempty"`
+	RemoteLastDownloaded *int64  `json:"remoteLastDownloaded,omitempty"` // Milliseconds since epoch
+}
+
+func (r ItemStatistics) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// Item statistics record the number of times an item was downloaded, last download date and last downloader.
+// Since: 3.1.0
+// Security: Requires a privileged user (can be anonymous)
+func (s *ArtifactService) ItemStatistics(ctx context.Context, repoKey string, itemPath string) (*ItemStatistics, *http.Response, error) {
+	path := fmt.Sprintf("/api/storage/%s/%s?stats", repoKey, itemPath)
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating new request: %v", err)
+	}
+	log.Debugf("[Artifactory Client] Storage API [%s]", req.URL.String())
+
+	stats := new(ItemStatistics)
+	resp, err := s.client.Do(ctx, req, stats)
+	return stats, resp, err
+}
+
+type BinariesSummary struct {
+	BinariesCount  *string `json:"binariesCount,omitempty"`
+	BinariesSize   *string `json:"binariesSize,omitempty"`
+	ArtifactsSize  *string `json:"artifactsSize,omitempty"`
+	Optimization   *string `json:"optimization,omitempty"`
+	ItemsCount     *string `json:"itemsCount,omitempty"`
+	ArtifactsCount *string `json:"artifactsCount,omitempty"`
+}
+
+type FileStoreSummary struct {
+	StorageType      *string `json:"storageType,omitempty"`
+	StorageDirectory *string `json:"storageDirectory,omitempty"`
+	TotalSpace       *string `json:"totalSpace,omitempty"`
+	UsedSpace        *string `json:"usedSpace,omitempty"`
+	FreeSpace        *string `json:"freeSpace,omitempty"`
+}
+
+type RepositorySummary struct {
+	RepoKey          *string `json:"repoKey,omitempty"`
+	RepoType         *string `json:"repoType,omitempty"`
+	FoldersCount     *int64  `json:"foldersCount,omitempty"`
+	FilesCount       *int64  `json:"filesCount,omitempty"`
+	UsedSpace        *string `json:"usedSpace,omitempty"`
+	UsedSpaceInBytes *int64  `json:"usedSpaceInBytes,omitempty"` // Since: 7.x
+	ItemsCount       *int64  `json:"itemsCount,omitempty"`
+	PackageType      *string `json:"packageType,omitempty"`
+	Percentage       *string `json:"percentage,omitempty"`
+}
+
+type StorageInfo struct {
+	BinariesSummary         *BinariesSummary     `json:"binariesSummary,omitempty"`
+	FileStoreSummary        *FileStoreSummary    `json:"fileStoreSummary,omitempty"`
+	RepositoriesSummaryList *[]RepositorySummary `json:"repositoriesSummaryList,omitempty"`
+}
+
+func (r StorageInfo) String() string {
+	res, _ := json.MarshalIndent(r, "", "    ")
+	return string(res)
+}
+
+// Returns storage summary information regarding binaries, file store and repositories.
+// Since: 4.2.0
+// Security: Requires a privileged user (Admin only)
+func (s *ArtifactService) GetStorageInfo(ctx context.Context) (*StorageInfo, *http.Response, error) {
+	path := "/api/storageinfo"
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	info := new(StorageInfo)
+	resp, err := s.client.Do(ctx, req, info)
+	return info, resp, err
+}
+
+// Refreshes the storage summary information, which is otherwise calculated periodically.
+// Since: 7.14.0
+// Security: Requires a privileged user (Admin only)
+func (s *ArtifactService) CalculateStorageInfo(ctx context.Context) (*http.Response, error) {
+	path := "/api/storageinfo/calculate"
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+var storageUnits = map[string]int64{
+	"bytes": 1,
+	"byte":  1,
+	"b":     1,
+	"kb":    1 << 10,
+	"mb":    1 << 20,
+	"gb":    1 << 30,
+	"tb":    1 << 40,
+	"pb":    1 << 50,
+}
+
+// ParseStorageSize converts the human readable sizes of the storage summary ("3.48 GB", "0 bytes",
+// "32.22 GB (15.77%)") to bytes
+func ParseStorageSize(size string) (int64, error) {
+	fields := strings.Fields(strings.Replace(size, ",", "", -1))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid storage size [%s]", size)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid storage size [%s]", size)
+	}
+	unit := int64(1)
+	if len(fields) > 1 && !strings.HasPrefix(fields[1], "(") {
+		var ok bool
+		if unit, ok = storageUnits[strings.ToLower(fields[1])]; !ok {
+			return 0, fmt.Errorf("invalid storage size unit [%s]", size)
+		}
+	}
+	return int64(value * float64(unit)), nil
+}
+
+// RepositoryUsage is the storage used by a repository, as reported by StorageUsageReport
+type RepositoryUsage struct {
+	RepoKey      string
+	RepoType     string
+	PackageType  string
+	FilesCount   int64
+	FoldersCount int64
+	UsedBytes    int64
+	GrowthBytes  int64 // Difference with the previous storage info, equal to UsedBytes if the repository is new
+}
+
+// StorageUsageReport is the per repository storage usage built from a storage info
+type StorageUsageReport struct {
+	Repositories []RepositoryUsage
+	TotalBytes   int64
+}
+
+// NewStorageUsageReport builds the usage report of the repositories in current. If previous is not nil, the growth
+// of each repository is computed against it. The TOTAL entry of the summary is not reported as a repository.
+func NewStorageUsageReport(current *StorageInfo, previous *StorageInfo) (*StorageUsageReport, error) {
+	previousBytes := map[string]int64{}
+	if previous != nil && previous.RepositoriesSummaryList != nil {
+		for _, r := range *previous.RepositoriesSummaryList {
+			usage, err := newRepositoryUsage(r)
+			if err != nil {
+				return nil, err
+			}
+			previousBytes[usage.RepoKey] = usage.UsedBytes
+		}
+	}
+
+	report := &StorageUsageReport{Repositories: make([]RepositoryUsage, 0)}
+	if current == nil || current.RepositoriesSummaryList == nil {
+		return report, nil
+	}
+	for _, r := range *current.RepositoriesSummaryList {
+		usage, err := newRepositoryUsage(r)
+		if err != nil {
+			return nil, err
+		}
+		if usage.RepoType == "NA" || usage.RepoKey == "TOTAL" {
+			continue
+		}
+		usage.GrowthBytes = usage.UsedBytes - previousBytes[usage.RepoKey]
+		report.TotalBytes += usage.UsedBytes
+		report.Repositories = append(report.Repositories, usage)
+	}
+	return report, nil
+}
+
+func newRepositoryUsage(r RepositorySummary) (RepositoryUsage, error) {
+	usage := RepositoryUsage{}
+	if r.RepoKey != nil {
+		usage.RepoKey = *r.RepoKey
+	}
+	if r.RepoType != nil {
+		usage.RepoType = *r.RepoType
+	}
+	if r.PackageType != nil {
+		usage.PackageType = *r.PackageType
+	}
+	if r.FilesCount != nil {
+		usage.FilesCount = *r.FilesCount
+	}
+	if r.FoldersCount != nil {
+		usage.FoldersCount = *r.FoldersCount
+	}
+	switch {
+	case r.UsedSpaceInBytes != nil:
+		usage.UsedBytes = *r.UsedSpaceInBytes
+	case r.UsedSpace != nil:
+		used, err := ParseStorageSize(*r.UsedSpace)
+		if err != nil {
+			return usage, fmt.Errorf("repository [%s]: %v", usage.RepoKey, err)
+		}
+		usage.UsedBytes = used
+	}
+	return usage, nil
+}
+
+// SortBySize orders the repositories by used space, largest first
+func (r *StorageUsageReport) SortBySize() {
+	sort.SliceStable(r.Repositories, func(i, j int) bool {
+		return r.Repositories[i].UsedBytes > r.Repositories[j].UsedBytes
+	})
+}
+
+// SortByGrowth orders the repositories by growth, fastest growing first
+func (r *StorageUsageReport) SortByGrowth() {
+	sort.SliceStable(r.Repositories, func(i, j int) bool {
+		return r.Repositories[i].GrowthBytes > r.Repositories[j].GrowthBytes
+	})
+}
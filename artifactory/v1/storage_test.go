@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemStatistics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/storage/libs-release/org/acme/lib.jar?stats", r.RequestURI)
+		_, _ = fmt.Fprint(w, `{
+  "uri": "http://localhost/artifactory/api/storage/libs-release/org/acme/lib.jar",
+  "lastDownloaded": 1389184882000,
+  "downloadCount": 15,
+  "lastDownloadedBy": "admin",
+  "remoteDownloadCount": 2,
+  "remoteLastDownloaded": 0
+}`)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	stats, _, err := v.Artifacts.ItemStatistics(context.Background(), "libs-release", "org/acme/lib.jar")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(15), *stats.DownloadCount)
+	assert.Equal(t, int64(1389184882000), *stats.LastDownloaded)
+	assert.Equal(t, "admin", *stats.LastDownloadedBy)
+	assert.Equal(t, int64(2), *stats.RemoteDownloadCount)
+}
+
+func TestStorageUsageReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/storageinfo", r.RequestURI)
+		_, _ = fmt.Fprint(w, `{
+  "binariesSummary": {"binariesCount": "125,726", "binariesSize": "3.48 GB", "artifactsSize": "59.77 GB", "optimization": "5.82%", "itemsCount": "2,176,580", "artifactsCount": "2,084,561"},
+  "fileStoreSummary": {"storageType": "filesystem", "storageDirectory": "/var/opt/jfrog/artifactory/data/filestore", "totalSpace": "204.28 GB", "usedSpace": "32.22 GB (15.77%)", "freeSpace": "172.06 GB (84.23%)"},
+  "repositoriesSummaryList": [
+    {"repoKey": "libs-release", "repoType": "LOCAL", "foldersCount": 10, "filesCount": 40, "usedSpace": "1.5 GB", "itemsCount": 50, "packageType": "Maven", "percentage": "10%"},
+    {"repoKey": "npm-remote-cache", "repoType": "CACHE", "foldersCount": 5, "filesCount": 80, "usedSpace": "512 MB", "itemsCount": 85, "packageType": "Npm", "percentage": "3%"},
+    {"repoKey": "docker-local", "repoType": "LOCAL", "foldersCount": 1, "filesCount": 2, "usedSpaceInBytes": 1024, "itemsCount": 3, "packageType": "Docker", "percentage": "0%"},
+    {"repoKey": "TOTAL", "repoType": "NA", "foldersCount": 16, "filesCount": 122, "usedSpace": "2.0 GB", "itemsCount": 138}
+  ]
+}`)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	info, _, err := v.Artifacts.GetStorageInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "3.48 GB", *info.BinariesSummary.BinariesSize)
+	assert.Equal(t, "filesystem", *info.FileStoreSummary.StorageType)
+	assert.Equal(t, 4, len(*info.RepositoriesSummaryList))
+
+	previous := &StorageInfo{RepositoriesSummaryList: &[]RepositorySummary{
+		{RepoKey: String("libs-release"), UsedSpace: String("1.4 GB")},
+		{RepoKey: String("npm-remote-cache"), UsedSpace: String("12 MB")},
+	}}
+	report, err := NewStorageUsageReport(info, previous)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(report.Repositories))
+
+	report.SortBySize()
+	assert.Equal(t, "libs-release", report.Repositories[0].RepoKey)
+	assert.Equal(t, int64(1.5*(1<<30)), report.Repositories[0].UsedBytes)
+	assert.Equal(t, "docker-local", report.Repositories[2].RepoKey)
+
+	report.SortByGrowth()
+	assert.Equal(t, "npm-remote-cache", report.Repositories[0].RepoKey)
+	assert.Equal(t, int64(500*(1<<20)), report.Repositories[0].GrowthBytes)
+}
+
+func TestParseStorageSize(t *testing.T) {
+	size, err := ParseStorageSize("0 bytes")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), size)
+
+	size, err = ParseStorageSize("32.5 KB (15.77%)")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(33280), size)
+
+	_, err = ParseStorageSize("a lot")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+)
+
+// trashRepository is the repository holding the deleted items, under their original repository and path
+const trashRepository = "auto-trashcan"
+
+// TrashItem is a file or folder in the trash can
+type TrashItem struct {
+	Repo   *string `json:"repo,omitempty"` // Repository the item was deleted from
+	Path   *string `json:"path,omitempty"` // Path of the item in the repository it was deleted from
+	Folder *bool   `json:"folder,omitempty"`
+}
+
+// Lists the content of the trash can. An empty path lists the repositories with deleted items, otherwise the path
+// is in the {repoKey}/{path} format of the items deleted.
+// Since: 4.4.2
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user (Admin only)
+func (s *ArtifactService) ListTrash(ctx context.Context, path string) ([]TrashItem, *http.Response, error) {
+	path = strings.Trim(path, "/")
+	info, resp, err := s.FolderInfo(ctx, trashRepository, path)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	items := make([]TrashItem, 0)
+	if info.Children == nil {
+		return items, resp, nil
+	}
+	for _, child := range *info.Children {
+		if child.Uri == nil {
+			continue
+		}
+		itemPath := strings.Trim(path+"/"+strings.Trim(*child.Uri, "/"), "/")
+		parts := strings.SplitN(itemPath, "/", 2)
+		item := TrashItem{Repo: String(parts[0]), Folder: child.Folder}
+		if len(parts) == 2 {
+			item.Path = String(parts[1])
+		}
+		items = append(items, item)
+	}
+	return items, resp, nil
+}
+
+type TrashRestoreOptions struct {
+	To string `url:"to,omitempty"` // Optional {repoKey}/{path} to restore to. Default: the original location
+}
+
+// Restore an item from the trash can to its original location or to the given one.
+// Since: 4.4.2
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user (Admin only)
+func (s *ArtifactService) RestoreFromTrash(ctx context.Context, repoKey string, itemPath string, opt *TrashRestoreOptions) (*string, *http.Response, error) {
+	path, err := client.AddOptions(fmt.Sprintf("/api/trash/restore/%s/%s", repoKey, itemPath), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypePlain)
+
+	buf := new(bytes.Buffer)
+	resp, err := s.client.Do(ctx, req, buf)
+	if err != nil {
+		return nil, resp, err
+	}
+	return String(buf.String()), resp, nil
+}
+
+// Permanently deletes an item from the trash can.
+// Since: 4.4.3
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user (Admin only)
+func (s *ArtifactService) DeleteFromTrash(ctx context.Context, repoKey string, itemPath string) (*http.Response, error) {
+	path := fmt.Sprintf("/api/trash/clean/%s/%s", repoKey, itemPath)
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+// Empties the trash can permanently deleting all its current contents.
+// Since: 4.4.3
+// Notes: Requires Artifactory Pro
+// Security: Requires a privileged user (Admin only)
+func (s *ArtifactService) EmptyTrash(ctx context.Context) (*http.Response, error) {
+	path := "/api/trash/empty"
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/listspa/go-artifactory/v2/artifactory/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTrash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		switch r.RequestURI {
+		case "/api/storage/auto-trashcan":
+			_, _ = fmt.Fprint(w, `{"repo": "auto-trashcan", "path": "/", "children": [{"uri": "/libs-release", "folder": true}]}`)
+		case "/api/storage/auto-trashcan/libs-release/org":
+			_, _ = fmt.Fprint(w, `{"repo": "auto-trashcan", "path": "/libs-release/org", "children": [{"uri": "/acme", "folder": true}, {"uri": "/readme.txt", "folder": false}]}`)
+		default:
+			t.Errorf("unexpected request [%s]", r.RequestURI)
+		}
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	items, _, err := v.Artifacts.ListTrash(context.Background(), "")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "libs-release", *items[0].Repo)
+	assert.Nil(t, items[0].Path)
+
+	items, _, err = v.Artifacts.ListTrash(context.Background(), "/libs-release/org/")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, "libs-release", *items[1].Repo)
+	assert.Equal(t, "org/readme.txt", *items[1].Path)
+	assert.False(t, *items[1].Folder)
+}
+
+func TestRestoreFromTrash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/trash/restore/libs-release/org/acme", r.URL.Path)
+		assert.Equal(t, "libs-restored/org/acme", r.URL.Query().Get("to"))
+		_, _ = fmt.Fprint(w, "Successfully restored trash items")
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	msg, _, err := v.Artifacts.RestoreFromTrash(context.Background(), "libs-release", "org/acme", &TrashRestoreOptions{To: "libs-restored/org/acme"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Successfully restored trash items", *msg)
+}
+
+func TestDeleteFromTrashAndEmpty(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.RequestURI)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, _ := client.NewClient(server.URL, http.DefaultClient)
+	v := NewV1(c)
+
+	_, err := v.Artifacts.DeleteFromTrash(context.Background(), "libs-release", "org/acme/lib.jar")
+	assert.Nil(t, err)
+	_, err = v.Artifacts.EmptyTrash(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"DELETE /api/trash/clean/libs-release/org/acme/lib.jar", "POST /api/trash/empty"}, requests)
+}
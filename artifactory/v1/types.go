@@ -17,6 +17,10 @@ const (
 	mediaTypeItemPermissions   = "application/vnd.org.jfrog.artifactory.storage.ItemPermissions+json"
 	mediaTypeReplicationConfig = "application/vnd.org.jfrog.artifactory.replications.ReplicationConfigRequest+json"
 	mediaTypeFileInfo          = "application/vnd.org.jfrog.artifactory.storage.FileInfo+json"
+	mediaTypeFolderInfo        = "application/vnd.org.jfrog.artifactory.storage.FolderInfo+json"
+
+	mediaTypeDistributionRepository = "application/vnd.org.jfrog.artifactory.repositories.DistributionRepositoryConfiguration+json"
+	mediaTypeFederatedRepository    = "application/vnd.org.jfrog.artifactory.repositories.FederatedRepositoryConfiguration+json"
 )
 
 type Service struct {
@@ -31,4 +35,5 @@ type V1 struct {
 	Security     *SecurityService
 	System       *SystemService
 	Artifacts    *ArtifactService
+	Builds       *BuildService
 }
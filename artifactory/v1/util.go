@@ -4,6 +4,16 @@ import "github.com/listspa/go-artifactory/v2/artifactory/client"
 
 func String(v string) *string { return &v }
 
+func Bool(v bool) *bool { return &v }
+
+// stringValue returns the string pointed to by v, or the empty string if v is nil
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
 func NewV1(client *client.Client) *V1 {
 	v := &V1{}
 	v.common.client = client
@@ -11,7 +21,8 @@ func NewV1(client *client.Client) *V1 {
 	v.Repositories = (*RepositoriesService)(&v.common)
 	v.Security = (*SecurityService)(&v.common)
 	v.System = (*SystemService)(&v.common)
-	v.Artifacts = (*ArtifactService)(&v.common)
+	v.Artifacts = &ArtifactService{Service: v.common}
+	v.Builds = (*BuildService)(&v.common)
 
 	return v
 }
@@ -37,6 +37,8 @@ type PermissionTarget struct {
 	Name  *string     `json:"name,omitempty"` // Optional element in create/replace queries
 	Repo  *Permission `json:"repo,omitempty"`
 	Build *Permission `json:"build,omitempty"`
+
+	unknownFields client.UnknownFields // Attributes Artifactory returned which aren't modelled
 }
 
 func (r PermissionTarget) String() string {
@@ -44,6 +46,22 @@ func (r PermissionTarget) String() string {
 	return string(res)
 }
 
+// GetUnknownFields returns the attributes returned by Artifactory which PermissionTarget doesn't model, sent back
+// unchanged on update.
+func (r PermissionTarget) GetUnknownFields() client.UnknownFields { return r.unknownFields }
+
+func (r PermissionTarget) MarshalJSON() ([]byte, error) {
+	type permissionTarget PermissionTarget
+	return client.MarshalWithUnknownFields(permissionTarget(r), r.unknownFields)
+}
+
+func (r *PermissionTarget) UnmarshalJSON(data []byte) error {
+	type permissionTarget PermissionTarget
+	unknown, err := client.UnmarshalKnownFields(data, (*permissionTarget)(r))
+	r.unknownFields = unknown
+	return err
+}
+
 func (s *SecurityService) CreatePermissionTarget(ctx context.Context, permissionName string, permissionTargets *PermissionTarget) (*http.Response, error) {
 	path := fmt.Sprintf("/api/v2/security/permissions/%s", permissionName)
 	req, err := s.client.NewJSONEncodedRequest(http.MethodPost, path, permissionTargets)